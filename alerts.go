@@ -0,0 +1,267 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+type ValidatorSnapshot struct {
+	Moniker         string `json:"moniker"`
+	Identity        string `json:"identity"`
+	Website         string `json:"website"`
+	SecurityContact string `json:"security_contact"`
+	Details         string `json:"details"`
+	Commission      string `json:"commission"`
+	Jailed          bool   `json:"jailed"`
+	Tombstoned      bool   `json:"tombstoned"`
+	Bonded          bool   `json:"bonded"`
+	MissedBlocks    int64  `json:"missed_blocks"`
+}
+
+func subscribeToValidator(message *tb.Message) {
+	args := strings.SplitAfterN(message.Text, " ", 2)
+	if len(args) < 2 {
+		log.Info().Msg("subscribeToValidator: args length < 2")
+		sendMessage(message, "Usage: subscribe_validator &lt;validator operator address or name&gt;")
+		return
+	}
+
+	address := args[1]
+	log.Debug().Str("address", address).Msg("subscribeToValidator: address")
+
+	validator, err := getValidator(address)
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get validator")
+		sendMessage(message, "Could not find validator")
+		return
+	}
+
+	stateMutex.Lock()
+	chats, added := addChatToList(state.ValidatorSubscriptions[validator.OperatorAddress], message.Chat.ID)
+	state.ValidatorSubscriptions[validator.OperatorAddress] = chats
+	saveState()
+	stateMutex.Unlock()
+
+	if !added {
+		sendMessage(message, fmt.Sprintf(
+			"This chat is already subscribed to <code>%s</code> alerts",
+			escapeHTML(validator.Description.Moniker),
+		))
+		return
+	}
+
+	sendMessage(message, fmt.Sprintf(
+		"Subscribed to <code>%s</code> alerts. Use <code>/unsubscribe_validator %s</code> to unsubscribe.",
+		escapeHTML(validator.Description.Moniker),
+		validator.OperatorAddress,
+	))
+	log.Info().
+		Str("query", address).
+		Str("validator", validator.OperatorAddress).
+		Int64("chat", message.Chat.ID).
+		Str("user", message.Sender.Username).
+		Msg("Successfully subscribed to validator")
+}
+
+func unsubscribeFromValidator(message *tb.Message) {
+	args := strings.SplitAfterN(message.Text, " ", 2)
+	if len(args) < 2 {
+		log.Info().Msg("unsubscribeFromValidator: args length < 2")
+		sendMessage(message, "Usage: unsubscribe_validator &lt;validator operator address or name&gt;")
+		return
+	}
+
+	address := args[1]
+	log.Debug().Str("address", address).Msg("unsubscribeFromValidator: address")
+
+	validator, err := getValidator(address)
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get validator")
+		sendMessage(message, "Could not find validator")
+		return
+	}
+
+	stateMutex.Lock()
+	chats, removed := removeChatFromList(state.ValidatorSubscriptions[validator.OperatorAddress], message.Chat.ID)
+	if len(chats) == 0 {
+		delete(state.ValidatorSubscriptions, validator.OperatorAddress)
+		delete(state.ValidatorSnapshots, validator.OperatorAddress)
+	} else {
+		state.ValidatorSubscriptions[validator.OperatorAddress] = chats
+	}
+	saveState()
+	stateMutex.Unlock()
+
+	if !removed {
+		sendMessage(message, fmt.Sprintf(
+			"This chat is not subscribed to <code>%s</code> alerts",
+			escapeHTML(validator.Description.Moniker),
+		))
+		return
+	}
+
+	sendMessage(message, fmt.Sprintf("Unsubscribed from <code>%s</code> alerts", escapeHTML(validator.Description.Moniker)))
+	log.Info().
+		Str("query", address).
+		Str("validator", validator.OperatorAddress).
+		Int64("chat", message.Chat.ID).
+		Str("user", message.Sender.Username).
+		Msg("Successfully unsubscribed from validator")
+}
+
+func startValidatorMonitor() {
+	for {
+		checkSubscribedValidators()
+		time.Sleep(ValidatorMonitorInterval)
+	}
+}
+
+func checkSubscribedValidators() {
+	stateMutex.Lock()
+	addresses := make([]string, 0, len(state.ValidatorSubscriptions))
+	for address := range state.ValidatorSubscriptions {
+		addresses = append(addresses, address)
+	}
+	stateMutex.Unlock()
+
+	for _, address := range addresses {
+		validator, err := getValidator(address)
+		if err != nil {
+			log.Error().Err(err).Str("address", address).Msg("Could not get validator for monitoring")
+			continue
+		}
+
+		snapshot, err := getValidatorSnapshot(validator)
+		if err != nil {
+			log.Error().Err(err).Str("address", address).Msg("Could not get validator snapshot")
+			continue
+		}
+
+		stateMutex.Lock()
+		previousSnapshot, found := state.ValidatorSnapshots[address]
+		state.ValidatorSnapshots[address] = snapshot
+		chats := getAlertChats(state.ValidatorSubscriptions[address])
+		saveState()
+		stateMutex.Unlock()
+
+		// first time we see this validator, nothing to compare with
+		if !found {
+			continue
+		}
+
+		alerts := getValidatorAlerts(previousSnapshot, snapshot)
+		if len(alerts) == 0 {
+			continue
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("<strong>%s</strong>\n", escapeHTML(snapshot.Moniker)))
+		sb.WriteString(fmt.Sprintf("<a href=\"https://mintscan.io/%s/validators/%s\">Mintscan</a>\n\n", MintscanPrefix, address))
+		for _, alert := range alerts {
+			sb.WriteString(alert + "\n")
+		}
+
+		for _, chat := range chats {
+			sendMessageToChat(chat, sb.String())
+		}
+
+		log.Info().
+			Str("validator", address).
+			Int("alerts", len(alerts)).
+			Int("chats", len(chats)).
+			Msg("Sent validator alerts")
+	}
+}
+
+func getValidatorSnapshot(validator stakingtypes.Validator) (ValidatorSnapshot, error) {
+	signingInfo, err := getValidatorSigningInfo(validator)
+	if err != nil {
+		return ValidatorSnapshot{}, err
+	}
+
+	return ValidatorSnapshot{
+		Moniker:         validator.Description.Moniker,
+		Identity:        validator.Description.Identity,
+		Website:         validator.Description.Website,
+		SecurityContact: validator.Description.SecurityContact,
+		Details:         validator.Description.Details,
+		Commission:      validator.Commission.CommissionRates.Rate.String(),
+		Jailed:          validator.Jailed,
+		Tombstoned:      signingInfo.Tombstoned,
+		Bonded:          validator.IsBonded(),
+		MissedBlocks:    signingInfo.MissedBlocksCounter,
+	}, nil
+}
+
+func getValidatorAlerts(previous ValidatorSnapshot, current ValidatorSnapshot) []string {
+	alerts := []string{}
+
+	if !previous.Tombstoned && current.Tombstoned {
+		alerts = append(alerts, "🔴 Validator was tombstoned")
+	}
+
+	if !previous.Jailed && current.Jailed {
+		alerts = append(alerts, "🔴 Validator was jailed")
+	} else if previous.Jailed && !current.Jailed {
+		alerts = append(alerts, "🟢 Validator was unjailed")
+	}
+
+	if previous.Bonded && !current.Bonded {
+		alerts = append(alerts, "🔴 Validator has left the active set")
+	} else if !previous.Bonded && current.Bonded {
+		alerts = append(alerts, "🟢 Validator has entered the active set")
+	}
+
+	if previous.Commission != current.Commission {
+		alerts = append(alerts, fmt.Sprintf(
+			"🟡 Commission rate changed: <code>%s</code> ➡️ <code>%s</code>",
+			formatCommission(previous.Commission),
+			formatCommission(current.Commission),
+		))
+	}
+
+	if previous.Moniker != current.Moniker {
+		alerts = append(alerts, fmt.Sprintf(
+			"🟡 Moniker changed: <code>%s</code> ➡️ <code>%s</code>",
+			escapeHTML(previous.Moniker),
+			escapeHTML(current.Moniker),
+		))
+	}
+
+	if previous.Identity != current.Identity ||
+		previous.Website != current.Website ||
+		previous.SecurityContact != current.SecurityContact ||
+		previous.Details != current.Details {
+		alerts = append(alerts, "🟡 Validator description changed")
+	}
+
+	if previous.MissedBlocks < MissedBlocksThreshold && current.MissedBlocks >= MissedBlocksThreshold {
+		alerts = append(alerts, fmt.Sprintf(
+			"🔴 Missed blocks counter is above the threshold: <code>%d</code> (threshold is <code>%d</code>)",
+			current.MissedBlocks,
+			MissedBlocksThreshold,
+		))
+	} else if previous.MissedBlocks >= MissedBlocksThreshold && current.MissedBlocks < MissedBlocksThreshold {
+		alerts = append(alerts, fmt.Sprintf(
+			"🟢 Missed blocks counter is back below the threshold: <code>%d</code>",
+			current.MissedBlocks,
+		))
+	}
+
+	return alerts
+}
+
+func formatCommission(commission string) string {
+	value, err := strconv.ParseFloat(commission, 64)
+	if err != nil {
+		return commission
+	}
+
+	return fmt.Sprintf("%.1f%%", value*100)
+}
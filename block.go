@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	tmrpc "github.com/tendermint/tendermint/rpc/client/http"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+func getBlockInfo(message *tb.Message) {
+	var height *int64
+
+	args := strings.SplitAfterN(message.Text, " ", 2)
+	if len(args) >= 2 {
+		parsedHeight, err := strconv.ParseInt(strings.TrimSpace(args[1]), 10, 64)
+		if err != nil {
+			log.Error().Err(err).Msg("getBlockInfo: Could not parse block")
+			sendMessage(message, "Block should be a number!")
+			return
+		}
+
+		height = &parsedHeight
+	}
+
+	// --------------------------------
+	block, err := getBlock(height)
+	if err != nil {
+		log.Error().Err(err).Msg("getBlockInfo: Could not get block")
+		sendMessage(message, "Could not get block info")
+		return
+	}
+
+	blockResults, err := getBlockResults(block.Height)
+	if err != nil {
+		log.Error().Err(err).Msg("getBlockInfo: Could not get block results")
+		sendMessage(message, "Could not get block results")
+		return
+	}
+
+	gasUsed := int64(0)
+	for _, txResult := range blockResults.TxsResults {
+		gasUsed += txResult.GasUsed
+	}
+
+	validators, err := getValidatorsByConsAddress()
+	if err != nil {
+		log.Error().Err(err).Msg("getBlockInfo: Could not get validators")
+	}
+
+	// --------------------------------
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<strong>Block #%d</strong>\n", block.Height))
+	sb.WriteString(fmt.Sprintf("<strong>Hash: </strong><code>%s</code>\n", block.Hash().String()))
+	sb.WriteString(fmt.Sprintf("<strong>Time: </strong><code>%s</code>\n", block.Time.Format(time.RFC822)))
+	sb.WriteString(fmt.Sprintf("<strong>Proposer: </strong>%s\n", serializeBlockProposer(block, validators)))
+	sb.WriteString(fmt.Sprintf("<strong>Transactions: </strong><code>%d</code>\n", len(block.Data.Txs)))
+	sb.WriteString(fmt.Sprintf("<strong>Gas used: </strong><code>%s</code>\n", Printer.Sprintf("%d", gasUsed)))
+	sb.WriteString(fmt.Sprintf("<strong>Evidence: </strong><code>%d</code>\n", len(block.Evidence.Evidence)))
+	sb.WriteString(fmt.Sprintf("<a href=\"https://mintscan.io/%s/blocks/%d\">Mintscan</a>\n", MintscanPrefix, block.Height))
+
+	sendMessage(message, sb.String())
+	log.Info().
+		Int64("height", block.Height).
+		Str("user", message.Sender.Username).
+		Msg("Successfully returned block info")
+}
+
+func getBlockResults(height int64) (*coretypes.ResultBlockResults, error) {
+	var blockResults *coretypes.ResultBlockResults
+	err := tendermintClient.Query(func(ctx context.Context, client *tmrpc.HTTP) error {
+		var err error
+		blockResults, err = client.BlockResults(ctx, &height)
+		return err
+	})
+
+	return blockResults, err
+}
+
+// getValidatorsByConsAddress returns the validators mapped by their consensus address in hex,
+// the way Tendermint returns it in blocks and consensus state.
+func getValidatorsByConsAddress() (map[string]stakingtypes.Validator, error) {
+	validators, err := getSortedValidators()
+	if err != nil {
+		return nil, err
+	}
+
+	validatorsMap := make(map[string]stakingtypes.Validator, len(validators))
+	for _, validator := range validators {
+		consAddress, err := getValidatorConsAddress(validator)
+		if err != nil {
+			continue
+		}
+
+		validatorsMap[strings.ToUpper(fmt.Sprintf("%x", consAddress.Bytes()))] = validator
+	}
+
+	return validatorsMap, nil
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	// so the timezones can be loaded on machines without tzdata installed
+	_ "time/tzdata"
+
+	tmrpc "github.com/tendermint/tendermint/rpc/client/http"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+var (
+	relativeFutureDateRegexp = regexp.MustCompile(`^in\s+(\d+)\s*([a-z]+)$`)
+	relativePastDateRegexp   = regexp.MustCompile(`^(\d+)\s*([a-z]+)\s+ago$`)
+
+	dateLayouts = []string{
+		time.RFC3339,
+		"2006-01-02T15:04:05",
+		"2006-01-02T15:04",
+		"2006-01-02 15:04:05",
+		"2006-01-02 15:04",
+		"2006-01-02",
+	}
+
+	dateUnits = map[string]time.Duration{
+		"s":       time.Second,
+		"sec":     time.Second,
+		"second":  time.Second,
+		"seconds": time.Second,
+		"m":       time.Minute,
+		"min":     time.Minute,
+		"minute":  time.Minute,
+		"minutes": time.Minute,
+		"h":       time.Hour,
+		"hour":    time.Hour,
+		"hours":   time.Hour,
+		"d":       24 * time.Hour,
+		"day":     24 * time.Hour,
+		"days":    24 * time.Hour,
+		"w":       7 * 24 * time.Hour,
+		"week":    7 * 24 * time.Hour,
+		"weeks":   7 * 24 * time.Hour,
+	}
+)
+
+type BlockInfo struct {
+	Height int64
+	Time   time.Time
+}
+
+func getBlockAtDate(message *tb.Message) {
+	args := strings.SplitAfterN(message.Text, " ", 2)
+	if len(args) < 2 {
+		log.Info().Msg("getBlockAtDate: args length < 2")
+		sendMessage(message, "Usage: blockat &lt;date&gt;, for example <code>/blockat 2021-08-01 12:00 Europe/Berlin</code>, <code>/blockat 3 days ago</code> or <code>/blockat in 2 weeks</code>")
+		return
+	}
+
+	date, err := parseDate(args[1], time.Now())
+	if err != nil {
+		log.Info().Err(err).Str("date", args[1]).Msg("getBlockAtDate: could not parse date")
+		sendMessage(message, fmt.Sprintf("Could not parse date: %s", escapeHTML(err.Error())))
+		return
+	}
+
+	log.Debug().Time("date", date).Msg("getBlockAtDate: date")
+
+	// --------------------------------
+	latestBlock, err := getBlock(nil)
+	if err != nil {
+		log.Error().Err(err).Msg("getBlockAtDate: Could not get latest block")
+		sendMessage(message, "Could not get block info")
+		return
+	}
+
+	if date.After(latestBlock.Time) {
+		avgBlockTime, err := getAverageBlockTime(latestBlock)
+		if err != nil {
+			log.Error().Err(err).Msg("getBlockAtDate: Could not get average block time")
+			sendMessage(message, "Could not get block info")
+			return
+		}
+
+		height := latestBlock.Height + int64(date.Sub(latestBlock.Time).Seconds()/avgBlockTime)
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("<strong>Estimated block at %s</strong>\n", date.Format(time.RFC822)))
+		sb.WriteString(fmt.Sprintf("<strong>Height: </strong><code>%d</code>\n", height))
+		sb.WriteString(fmt.Sprintf("<strong>Average block time: </strong><code>%.2fs</code>\n", avgBlockTime))
+
+		sendMessage(message, sb.String())
+		log.Info().
+			Time("date", date).
+			Int64("height", height).
+			Str("user", message.Sender.Username).
+			Msg("Successfully returned estimated block at date")
+		return
+	}
+
+	block, err := findBlockAtDate(date, latestBlock.Height)
+	if err != nil {
+		log.Error().Err(err).Msg("getBlockAtDate: Could not find block")
+		sendMessage(message, fmt.Sprintf("Could not find block: %s", escapeHTML(err.Error())))
+		return
+	}
+
+	// --------------------------------
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<strong>Block at %s</strong>\n", date.Format(time.RFC822)))
+	sb.WriteString(fmt.Sprintf("<strong>Height: </strong><code>%d</code>\n", block.Height))
+	sb.WriteString(fmt.Sprintf("<strong>Generation time: </strong><code>%s</code>\n", block.Time.Format(time.RFC822)))
+	sb.WriteString(fmt.Sprintf("<a href=\"https://mintscan.io/%s/blocks/%d\">Mintscan</a>\n", MintscanPrefix, block.Height))
+
+	sendMessage(message, sb.String())
+	log.Info().
+		Time("date", date).
+		Int64("height", block.Height).
+		Str("user", message.Sender.Username).
+		Msg("Successfully returned block at date")
+}
+
+// findBlockAtDate finds the latest block generated before or at the given time using binary search.
+func findBlockAtDate(date time.Time, latestHeight int64) (*BlockInfo, error) {
+	earliestHeight, err := getEarliestBlockHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	earliestBlock, err := getBlock(&earliestHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	if date.Before(earliestBlock.Time) {
+		return nil, fmt.Errorf(
+			"the date is before the earliest block available on the node (#%d, %s)",
+			earliestHeight,
+			earliestBlock.Time.Format(time.RFC822),
+		)
+	}
+
+	result := &BlockInfo{Height: earliestBlock.Height, Time: earliestBlock.Time}
+	low, high := earliestHeight+1, latestHeight
+
+	for low <= high {
+		middle := low + (high-low)/2
+		block, err := getBlock(&middle)
+		if err != nil {
+			return nil, err
+		}
+
+		if block.Time.After(date) {
+			high = middle - 1
+		} else {
+			result = &BlockInfo{Height: block.Height, Time: block.Time}
+			low = middle + 1
+		}
+	}
+
+	return result, nil
+}
+
+func getEarliestBlockHeight() (int64, error) {
+	var status *coretypes.ResultStatus
+	err := tendermintClient.Query(func(ctx context.Context, client *tmrpc.HTTP) error {
+		var err error
+		status, err = client.Status(ctx)
+		return err
+	})
+
+	if err != nil {
+		log.Error().Err(err).Msg("Could not query Tendermint status")
+		return 0, err
+	}
+
+	// pruned nodes might report 0 if they don't know it
+	if status.SyncInfo.EarliestBlockHeight == 0 {
+		return 1, nil
+	}
+
+	return status.SyncInfo.EarliestBlockHeight, nil
+}
+
+// parseDate parses the absolute dates in ISO 8601 format with an optional timezone
+// (like "2021-08-01 12:00 Europe/Berlin" or "2021-08-01T12:00:00+02:00")
+// and relative ones (like "in 3 days" or "5 hours ago").
+func parseDate(input string, now time.Time) (time.Time, error) {
+	input = strings.TrimSpace(input)
+	lowercased := strings.ToLower(input)
+
+	if lowercased == "now" {
+		return now, nil
+	}
+
+	if match := relativeFutureDateRegexp.FindStringSubmatch(lowercased); match != nil {
+		duration, err := parseRelativeDuration(match[1], match[2])
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		return now.Add(duration), nil
+	}
+
+	if match := relativePastDateRegexp.FindStringSubmatch(lowercased); match != nil {
+		duration, err := parseRelativeDuration(match[1], match[2])
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		return now.Add(-duration), nil
+	}
+
+	location := time.UTC
+	fields := strings.Fields(input)
+
+	// the timezone can be passed as the last argument, either as a name or as an offset
+	if len(fields) > 1 {
+		lastField := fields[len(fields)-1]
+		if tz, err := time.LoadLocation(lastField); err == nil {
+			location = tz
+			fields = fields[:len(fields)-1]
+		} else if offset, err := time.Parse("-07:00", lastField); err == nil {
+			_, seconds := offset.Zone()
+			location = time.FixedZone(lastField, seconds)
+			fields = fields[:len(fields)-1]
+		}
+	}
+
+	date := strings.Join(fields, " ")
+	for _, layout := range dateLayouts {
+		if parsed, err := time.ParseInLocation(layout, date, location); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unsupported date format, use something like 2021-08-01 12:00 UTC, in 3 days or 5 hours ago")
+}
+
+func parseRelativeDuration(amount string, unit string) (time.Duration, error) {
+	value, err := strconv.ParseInt(amount, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	unitDuration, ok := dateUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("unsupported time unit: %s", unit)
+	}
+
+	return time.Duration(value) * unitDuration, nil
+}
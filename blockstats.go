@@ -0,0 +1,308 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	ctypes "github.com/tendermint/tendermint/types"
+
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+const (
+	DefaultBlockStatsWindow = 100
+	MaxBlockStatsWindow     = 1000
+	// how many blocks are fetched at once
+	BlocksFetchBatchSize = 20
+	SlowestBlocksCount   = 5
+)
+
+func getBlockTimeStats(message *tb.Message) {
+	window, ok := parseBlockStatsWindow(message.Text)
+	if !ok {
+		sendMessage(message, fmt.Sprintf("Usage: blocktime [blocks amount, from 2 to %d]", MaxBlockStatsWindow))
+		return
+	}
+
+	// --------------------------------
+	// one more block is needed, as block times are the differences between the neighbouring blocks
+	blocks, err := getLatestBlocks(window + 1)
+	if err != nil {
+		log.Error().Err(err).Msg("getBlockTimeStats: Could not get blocks")
+		sendMessage(message, "Could not get blocks")
+		return
+	}
+
+	if len(blocks) < 2 {
+		sendMessage(message, "Not enough blocks to calculate block time")
+		return
+	}
+
+	type blockTime struct {
+		Block    *ctypes.Block
+		Duration time.Duration
+	}
+
+	blockTimes := make([]blockTime, len(blocks)-1)
+	durations := make([]time.Duration, len(blocks)-1)
+	total := time.Duration(0)
+
+	for index := 1; index < len(blocks); index++ {
+		duration := blocks[index].Time.Sub(blocks[index-1].Time)
+		blockTimes[index-1] = blockTime{Block: blocks[index], Duration: duration}
+		durations[index-1] = duration
+		total += duration
+	}
+
+	sort.Slice(durations, func(i, j int) bool {
+		return durations[i] < durations[j]
+	})
+
+	sort.SliceStable(blockTimes, func(i, j int) bool {
+		return blockTimes[i].Duration > blockTimes[j].Duration
+	})
+
+	validators, err := getValidatorsByConsAddress()
+	if err != nil {
+		log.Error().Err(err).Msg("getBlockTimeStats: Could not get validators")
+	}
+
+	// --------------------------------
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(
+		"<strong>Block time over the last %d blocks (#%d - #%d)</strong>\n",
+		len(durations),
+		blocks[1].Height,
+		blocks[len(blocks)-1].Height,
+	))
+	sb.WriteString(fmt.Sprintf("<strong>Average: </strong><code>%s</code>\n", formatBlockTime(total/time.Duration(len(durations)))))
+	sb.WriteString(fmt.Sprintf("<strong>Median: </strong><code>%s</code>\n", formatBlockTime(getPercentile(durations, 50))))
+	sb.WriteString(fmt.Sprintf("<strong>95th percentile: </strong><code>%s</code>\n", formatBlockTime(getPercentile(durations, 95))))
+	sb.WriteString(fmt.Sprintf("<strong>Max: </strong><code>%s</code>\n", formatBlockTime(durations[len(durations)-1])))
+
+	sb.WriteString("\n<strong>Slowest blocks:</strong>\n")
+	for index, item := range blockTimes {
+		if index >= SlowestBlocksCount {
+			break
+		}
+
+		sb.WriteString(fmt.Sprintf(
+			"- <a href=\"https://mintscan.io/%s/blocks/%d\">#%d</a>: <code>%s</code>, proposed by %s\n",
+			MintscanPrefix,
+			item.Block.Height,
+			item.Block.Height,
+			formatBlockTime(item.Duration),
+			serializeBlockProposer(item.Block, validators),
+		))
+	}
+
+	sendMessage(message, sb.String())
+	log.Info().
+		Int64("window", window).
+		Str("user", message.Sender.Username).
+		Msg("Successfully returned block time stats")
+}
+
+func getProposersStats(message *tb.Message) {
+	window, ok := parseBlockStatsWindow(message.Text)
+	if !ok {
+		sendMessage(message, fmt.Sprintf("Usage: proposers [blocks amount, from 2 to %d]", MaxBlockStatsWindow))
+		return
+	}
+
+	// --------------------------------
+	blocks, err := getLatestBlocks(window)
+	if err != nil {
+		log.Error().Err(err).Msg("getProposersStats: Could not get blocks")
+		sendMessage(message, "Could not get blocks")
+		return
+	}
+
+	validators, err := getValidatorsByConsAddress()
+	if err != nil {
+		log.Error().Err(err).Msg("getProposersStats: Could not get validators")
+		sendMessage(message, "Could not get validators")
+		return
+	}
+
+	proposed := make(map[string]int)
+	for _, block := range blocks {
+		proposed[block.ProposerAddress.String()]++
+	}
+
+	type proposerStats struct {
+		Moniker  string
+		Address  string
+		Proposed int
+		Expected float64
+	}
+
+	stats := []proposerStats{}
+	bondedTokens := float64(0)
+
+	for consAddress, validator := range validators {
+		if !validator.IsBonded() {
+			continue
+		}
+
+		tokens, err := intToFloat(validator.Tokens)
+		if err != nil {
+			log.Error().
+				Str("address", validator.OperatorAddress).
+				Err(err).
+				Msg("Could not parse validator tokens")
+			continue
+		}
+
+		bondedTokens += tokens
+		stats = append(stats, proposerStats{
+			Moniker:  validator.Description.Moniker,
+			Address:  validator.OperatorAddress,
+			Proposed: proposed[consAddress],
+			Expected: tokens,
+		})
+	}
+
+	for index := range stats {
+		stats[index].Expected = stats[index].Expected / bondedTokens * float64(len(blocks))
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Expected > stats[j].Expected
+	})
+
+	// --------------------------------
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(
+		"<strong>Proposers over the last %d blocks (#%d - #%d)</strong>\n",
+		len(blocks),
+		blocks[0].Height,
+		blocks[len(blocks)-1].Height,
+	))
+	sb.WriteString("Proposed blocks / expected by voting power\n\n")
+
+	for index, item := range stats {
+		sb.WriteString(fmt.Sprintf(
+			"%d. <a href=\"https://mintscan.io/%s/validators/%s\">%s</a> - <code>%d</code> / <code>%.1f</code>\n",
+			index+1,
+			MintscanPrefix,
+			item.Address,
+			escapeHTML(item.Moniker),
+			item.Proposed,
+			item.Expected,
+		))
+	}
+
+	for _, chunk := range splitMessage(sb.String()) {
+		sendMessage(message, chunk)
+	}
+
+	log.Info().
+		Int64("window", window).
+		Str("user", message.Sender.Username).
+		Msg("Successfully returned proposers stats")
+}
+
+// parseBlockStatsWindow returns the amount of blocks to calculate the stats over,
+// DefaultBlockStatsWindow if it's not provided.
+func parseBlockStatsWindow(text string) (int64, bool) {
+	args := strings.Fields(text)
+	if len(args) < 2 {
+		return DefaultBlockStatsWindow, true
+	}
+
+	window, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil || window < 2 || window > MaxBlockStatsWindow {
+		return 0, false
+	}
+
+	return window, true
+}
+
+// getLatestBlocks returns the latest blocks sorted by height ascending. The blocks are fetched
+// concurrently, BlocksFetchBatchSize at a time, so the node won't be flooded with requests.
+func getLatestBlocks(count int64) ([]*ctypes.Block, error) {
+	latestBlock, err := getBlock(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if count > latestBlock.Height {
+		count = latestBlock.Height
+	}
+
+	blocks := make([]*ctypes.Block, count)
+	blocks[count-1] = latestBlock
+
+	for batchStart := int64(0); batchStart < count-1; batchStart += BlocksFetchBatchSize {
+		batchEnd := int64(math.Min(float64(batchStart+BlocksFetchBatchSize), float64(count-1)))
+
+		var wg sync.WaitGroup
+		var mutex sync.Mutex
+		var fetchErr error
+
+		for index := batchStart; index < batchEnd; index++ {
+			wg.Add(1)
+
+			go func(index int64) {
+				defer wg.Done()
+
+				height := latestBlock.Height - count + 1 + index
+				block, err := getBlock(&height)
+
+				mutex.Lock()
+				defer mutex.Unlock()
+
+				if err != nil {
+					fetchErr = err
+					return
+				}
+
+				blocks[index] = block
+			}(index)
+		}
+
+		wg.Wait()
+
+		if fetchErr != nil {
+			return nil, fetchErr
+		}
+	}
+
+	return blocks, nil
+}
+
+func serializeBlockProposer(block *ctypes.Block, validators map[string]stakingtypes.Validator) string {
+	if validator, ok := validators[block.ProposerAddress.String()]; ok {
+		return fmt.Sprintf(
+			"<a href=\"https://mintscan.io/%s/validators/%s\">%s</a>",
+			MintscanPrefix,
+			validator.OperatorAddress,
+			escapeHTML(validator.Description.Moniker),
+		)
+	}
+
+	return fmt.Sprintf("<code>%s</code>", block.ProposerAddress.String())
+}
+
+// getPercentile returns the percentile of the sorted durations using the nearest-rank method.
+func getPercentile(sorted []time.Duration, percentile float64) time.Duration {
+	rank := int(math.Ceil(percentile / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
+
+func formatBlockTime(duration time.Duration) string {
+	return fmt.Sprintf("%.2fs", duration.Seconds())
+}
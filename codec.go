@@ -0,0 +1,46 @@
+package main
+
+import (
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/std"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	crisistypes "github.com/cosmos/cosmos-sdk/x/crisis/types"
+	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	evidencetypes "github.com/cosmos/cosmos-sdk/x/evidence/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	ibctransfertypes "github.com/cosmos/cosmos-sdk/x/ibc/applications/transfer/types"
+	ibcclienttypes "github.com/cosmos/cosmos-sdk/x/ibc/core/02-client/types"
+	ibccoretypes "github.com/cosmos/cosmos-sdk/x/ibc/core/types"
+	paramstypes "github.com/cosmos/cosmos-sdk/x/params/types/proposal"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+)
+
+// getInterfaceRegistry returns the registry with all the types the bot
+// might need to unpack from Any: pubkeys, proposal contents, transaction messages etc.
+func getInterfaceRegistry() codectypes.InterfaceRegistry {
+	registry := codectypes.NewInterfaceRegistry()
+
+	std.RegisterInterfaces(registry)
+	authtypes.RegisterInterfaces(registry)
+	vestingtypes.RegisterInterfaces(registry)
+	banktypes.RegisterInterfaces(registry)
+	stakingtypes.RegisterInterfaces(registry)
+	slashingtypes.RegisterInterfaces(registry)
+	crisistypes.RegisterInterfaces(registry)
+	evidencetypes.RegisterInterfaces(registry)
+	govtypes.RegisterInterfaces(registry)
+	upgradetypes.RegisterInterfaces(registry)
+	paramstypes.RegisterInterfaces(registry)
+	distributiontypes.RegisterInterfaces(registry)
+	ibccoretypes.RegisterInterfaces(registry)
+	ibctransfertypes.RegisterInterfaces(registry)
+
+	// ibc module doesn't register its proposal as gov content by itself
+	registry.RegisterImplementations((*govtypes.Content)(nil), &ibcclienttypes.ClientUpdateProposal{})
+
+	return registry
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+type ValidatorRewards struct {
+	OutstandingRewards sdk.DecCoins
+	Commission         sdk.DecCoins
+}
+
+func getValidatorRewards(validator stakingtypes.Validator) (ValidatorRewards, error) {
+	distributionClient := distributiontypes.NewQueryClient(grpcConn)
+	outstandingRewardsResponse, err := distributionClient.ValidatorOutstandingRewards(
+		context.Background(),
+		&distributiontypes.QueryValidatorOutstandingRewardsRequest{ValidatorAddress: validator.OperatorAddress},
+	)
+
+	if err != nil {
+		log.Error().
+			Str("address", validator.OperatorAddress).
+			Err(err).
+			Msg("Could not get validator outstanding rewards")
+		return ValidatorRewards{}, err
+	}
+
+	commissionResponse, err := distributionClient.ValidatorCommission(
+		context.Background(),
+		&distributiontypes.QueryValidatorCommissionRequest{ValidatorAddress: validator.OperatorAddress},
+	)
+
+	if err != nil {
+		log.Error().
+			Str("address", validator.OperatorAddress).
+			Err(err).
+			Msg("Could not get validator commission")
+		return ValidatorRewards{}, err
+	}
+
+	return ValidatorRewards{
+		OutstandingRewards: outstandingRewardsResponse.Rewards.Rewards,
+		Commission:         commissionResponse.Commission.Commission,
+	}, nil
+}
+
+// getValidatorDailyCommission estimates how much the validator gets as commission per day,
+// based on the current inflation, bonded ratio and community tax.
+func getValidatorDailyCommission(validator stakingtypes.Validator) (float64, error) {
+	mintClient := minttypes.NewQueryClient(grpcConn)
+	inflationResponse, err := mintClient.Inflation(
+		context.Background(),
+		&minttypes.QueryInflationRequest{},
+	)
+
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get inflation")
+		return 0, err
+	}
+
+	bondDenom, err := getBondDenom()
+	if err != nil {
+		return 0, err
+	}
+
+	stakingClient := stakingtypes.NewQueryClient(grpcConn)
+	poolResponse, err := stakingClient.Pool(
+		context.Background(),
+		&stakingtypes.QueryPoolRequest{},
+	)
+
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get staking pool")
+		return 0, err
+	}
+
+	bankClient := banktypes.NewQueryClient(grpcConn)
+	supplyResponse, err := bankClient.SupplyOf(
+		context.Background(),
+		&banktypes.QuerySupplyOfRequest{Denom: bondDenom},
+	)
+
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get total supply")
+		return 0, err
+	}
+
+	distributionClient := distributiontypes.NewQueryClient(grpcConn)
+	distributionParamsResponse, err := distributionClient.Params(
+		context.Background(),
+		&distributiontypes.QueryParamsRequest{},
+	)
+
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get distribution params")
+		return 0, err
+	}
+
+	if poolResponse.Pool.BondedTokens.IsZero() {
+		return 0, fmt.Errorf("there are no bonded tokens")
+	}
+
+	inflation, err := decToFloat(inflationResponse.Inflation)
+	if err != nil {
+		return 0, err
+	}
+
+	communityTax, err := decToFloat(distributionParamsResponse.Params.CommunityTax)
+	if err != nil {
+		return 0, err
+	}
+
+	commissionRate, err := decToFloat(validator.Commission.CommissionRates.Rate)
+	if err != nil {
+		return 0, err
+	}
+
+	supply, err := intToFloat(supplyResponse.Amount.Amount)
+	if err != nil {
+		return 0, err
+	}
+
+	bondedTokens, err := intToFloat(poolResponse.Pool.BondedTokens)
+	if err != nil {
+		return 0, err
+	}
+
+	validatorTokens, err := intToFloat(validator.Tokens)
+	if err != nil {
+		return 0, err
+	}
+
+	bondedRatio := bondedTokens / supply
+
+	log.Debug().
+		Float64("inflation", inflation).
+		Float64("bondedRatio", bondedRatio).
+		Float64("communityTax", communityTax).
+		Msg("Daily commission calculation params")
+
+	// the newly minted tokens are distributed between the bonded tokens proportionally,
+	// so the validator's yearly rewards are inflation * supply * tokens / bonded tokens
+	yearlyRewards := inflation * validatorTokens / bondedRatio * (1 - communityTax)
+	return yearlyRewards * commissionRate / 365, nil
+}
+
+func getBondDenom() (string, error) {
+	stakingClient := stakingtypes.NewQueryClient(grpcConn)
+	paramsResponse, err := stakingClient.Params(
+		context.Background(),
+		&stakingtypes.QueryParamsRequest{},
+	)
+
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get staking params")
+		return "", err
+	}
+
+	return paramsResponse.Params.BondDenom, nil
+}
+
+// serializeDecCoins converts the bond denom amounts into the display units,
+// leaving other coins (like IBC ones) as is.
+func serializeDecCoins(coins sdk.DecCoins, bondDenom string) string {
+	if coins.Empty() {
+		return Printer.Sprintf("<code>%.2f %s</code>", 0.0, Denom)
+	}
+
+	serialized := make([]string, len(coins))
+
+	for index, coin := range coins {
+		value, err := decToFloat(coin.Amount)
+		if err != nil {
+			log.Error().Err(err).Str("denom", coin.Denom).Msg("Could not parse coin amount")
+			serialized[index] = fmt.Sprintf("<code>%s %s</code>", coin.Amount.String(), coin.Denom)
+			continue
+		}
+
+		if coin.Denom == bondDenom {
+			serialized[index] = Printer.Sprintf("<code>%.2f %s</code>", value/DenomCoefficient, Denom)
+		} else {
+			serialized[index] = Printer.Sprintf("<code>%.0f %s</code>", value, coin.Denom)
+		}
+	}
+
+	return strings.Join(serialized, " ")
+}
+
+func serializeCoins(coins sdk.Coins, bondDenom string) string {
+	return serializeDecCoins(sdk.NewDecCoinsFromCoins(coins...), bondDenom)
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// CompareMonikerMaxWidth is the max width of a validator moniker in /compare table,
+// the longer ones are truncated. Other values are never cut, so the columns are as wide as needed.
+const CompareMonikerMaxWidth = 12
+
+type ValidatorComparison struct {
+	Moniker        string
+	Rank           string
+	VotingPower    string
+	Commission     string
+	MaxCommission  string
+	SelfDelegation string
+	Uptime         string
+	Jailed         string
+	Governance     string
+}
+
+func compareValidators(message *tb.Message) {
+	args := strings.Fields(message.Text)
+	if len(args) < 3 {
+		log.Info().Msg("compareValidators: args length < 3")
+		sendMessage(message, "Usage: compare &lt;validator address or name&gt; &lt;validator address or name&gt; [...]")
+		return
+	}
+
+	// --------------------------------
+	stakingClient := stakingtypes.NewQueryClient(grpcConn)
+	poolResponse, err := stakingClient.Pool(
+		context.Background(),
+		&stakingtypes.QueryPoolRequest{},
+	)
+
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get staking pool")
+		sendMessage(message, "Could not get staking pool")
+		return
+	}
+
+	slashingClient := slashingtypes.NewQueryClient(grpcConn)
+	slashingParamsResponse, err := slashingClient.Params(
+		context.Background(),
+		&slashingtypes.QueryParamsRequest{},
+	)
+
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get slashing params")
+		sendMessage(message, "Could not get slashing params")
+		return
+	}
+
+	bondedTokens, err := intToFloat(poolResponse.Pool.BondedTokens)
+	if err != nil {
+		log.Error().Err(err).Msg("Could not parse bonded tokens")
+		sendMessage(message, "Could not parse bonded tokens")
+		return
+	}
+
+	validators := make([]stakingtypes.Validator, len(args)-1)
+	for index, address := range args[1:] {
+		validator, err := getValidator(address)
+		if err != nil {
+			log.Error().Err(err).Str("address", address).Msg("Could not get validator")
+			sendMessage(message, fmt.Sprintf("Could not find validator <code>%s</code>", escapeHTML(address)))
+			return
+		}
+
+		validators[index] = validator
+	}
+
+	// these are the same for every validator, so fetching them once instead of for every column
+	ranks := map[string]int{}
+	if sortedValidators, err := getSortedValidators(); err != nil {
+		log.Error().Err(err).Msg("Could not get validators")
+	} else {
+		for index, validator := range sortedValidators {
+			ranks[validator.OperatorAddress] = index + 1
+		}
+	}
+
+	proposals, proposalsErr := getProposals(govtypes.StatusNil)
+	if proposalsErr != nil {
+		log.Error().Err(proposalsErr).Msg("Could not get proposals")
+	}
+
+	// each column takes quite a few requests, so getting them concurrently
+	comparisons := make([]ValidatorComparison, len(validators))
+	var wg sync.WaitGroup
+
+	for index, validator := range validators {
+		wg.Add(1)
+
+		go func(index int, validator stakingtypes.Validator) {
+			defer wg.Done()
+
+			comparisons[index] = getValidatorComparison(
+				validator,
+				bondedTokens,
+				slashingParamsResponse.Params.SignedBlocksWindow,
+				ranks,
+				proposals,
+				proposalsErr == nil,
+			)
+		}(index, validator)
+	}
+
+	wg.Wait()
+
+	// --------------------------------
+
+	rows := []struct {
+		Title string
+		Value func(ValidatorComparison) string
+	}{
+		{"Rank", func(c ValidatorComparison) string { return c.Rank }},
+		{"Voting power", func(c ValidatorComparison) string { return c.VotingPower }},
+		{"Commission", func(c ValidatorComparison) string { return c.Commission }},
+		{"Max commission", func(c ValidatorComparison) string { return c.MaxCommission }},
+		{"Self-delegation", func(c ValidatorComparison) string { return c.SelfDelegation }},
+		{"Uptime", func(c ValidatorComparison) string { return c.Uptime }},
+		{"Jailed", func(c ValidatorComparison) string { return c.Jailed }},
+		{"Governance", func(c ValidatorComparison) string { return c.Governance }},
+	}
+
+	titleWidth := 0
+	for _, row := range rows {
+		if len(row.Title) > titleWidth {
+			titleWidth = len(row.Title)
+		}
+	}
+
+	monikers := make([]string, len(comparisons))
+	columnWidths := make([]int, len(comparisons))
+	for index, comparison := range comparisons {
+		monikers[index] = truncateString(comparison.Moniker, CompareMonikerMaxWidth)
+		columnWidths[index] = utf8.RuneCountInString(monikers[index])
+
+		for _, row := range rows {
+			if width := utf8.RuneCountInString(row.Value(comparison)); width > columnWidths[index] {
+				columnWidths[index] = width
+			}
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<pre>")
+	sb.WriteString(fmt.Sprintf("%-*s", titleWidth, ""))
+	for index, moniker := range monikers {
+		sb.WriteString(" | " + escapeHTML(padString(moniker, columnWidths[index])))
+	}
+	sb.WriteString("\n")
+
+	for _, row := range rows {
+		sb.WriteString(fmt.Sprintf("%-*s", titleWidth, row.Title))
+		for index, comparison := range comparisons {
+			sb.WriteString(" | " + padString(row.Value(comparison), columnWidths[index]))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("</pre>")
+
+	sendMessage(message, sb.String())
+	log.Info().
+		Str("query", message.Text).
+		Str("user", message.Sender.Username).
+		Msg("Successfully returned validators comparison")
+}
+
+// getValidatorComparison collects everything for a single /compare column,
+// putting "?" for the values it could not get instead of failing the whole command.
+// Ranks are mapped by the operator address, proposals are used only if proposalsFetched is true.
+func getValidatorComparison(
+	validator stakingtypes.Validator,
+	bondedTokens float64,
+	signedBlocksWindow int64,
+	ranks map[string]int,
+	proposals []govtypes.Proposal,
+	proposalsFetched bool,
+) ValidatorComparison {
+	comparison := ValidatorComparison{
+		Moniker:        validator.Description.Moniker,
+		Rank:           "?",
+		VotingPower:    "?",
+		Commission:     "?",
+		MaxCommission:  "?",
+		SelfDelegation: "?",
+		Uptime:         "?",
+		Jailed:         "no",
+		Governance:     "?",
+	}
+
+	if validator.Jailed {
+		comparison.Jailed = "yes"
+		comparison.Rank = "jailed"
+	} else if rank, ok := ranks[validator.OperatorAddress]; ok {
+		comparison.Rank = strconv.Itoa(rank)
+	}
+
+	if tokens, err := intToFloat(validator.Tokens); err == nil {
+		if validator.IsBonded() && bondedTokens > 0 {
+			comparison.VotingPower = fmt.Sprintf("%.2f%%", tokens/bondedTokens*100)
+		} else {
+			comparison.VotingPower = "0%"
+		}
+	}
+
+	comparison.Commission = formatCommission(validator.Commission.CommissionRates.Rate.String())
+	comparison.MaxCommission = formatCommission(validator.Commission.CommissionRates.MaxRate.String())
+
+	if selfDelegation, err := getValidatorSelfDelegation(validator); err != nil {
+		log.Error().Err(err).Str("address", validator.OperatorAddress).Msg("Could not get self-delegation")
+	} else {
+		comparison.SelfDelegation = Printer.Sprintf("%.0f %s", selfDelegation/DenomCoefficient, Denom)
+	}
+
+	if signingInfo, err := getValidatorSigningInfo(validator); err == nil && signedBlocksWindow > 0 {
+		uptime := 1 - float64(signingInfo.MissedBlocksCounter)/float64(signedBlocksWindow)
+		comparison.Uptime = fmt.Sprintf("%.2f%%", uptime*100)
+	}
+
+	if !proposalsFetched {
+		return comparison
+	}
+
+	if participation, err := getGovParticipation(validator, proposals); err != nil {
+		log.Error().Err(err).Str("address", validator.OperatorAddress).Msg("Could not get governance participation")
+	} else if !participation.FinishedUnavailable {
+		comparison.Governance = fmt.Sprintf("%d/%d", participation.FinishedVoted, participation.FinishedTotal)
+	}
+
+	return comparison
+}
+
+func getValidatorSelfDelegation(validator stakingtypes.Validator) (float64, error) {
+	delegator, err := getValidatorAccountAddress(validator)
+	if err != nil {
+		return 0, err
+	}
+
+	stakingClient := stakingtypes.NewQueryClient(grpcConn)
+	delegationResponse, err := stakingClient.Delegation(
+		context.Background(),
+		&stakingtypes.QueryDelegationRequest{
+			DelegatorAddr: delegator,
+			ValidatorAddr: validator.OperatorAddress,
+		},
+	)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return intToFloat(delegationResponse.DelegationResponse.Balance.Amount)
+}
+
+func truncateString(str string, length int) string {
+	runes := []rune(str)
+	if len(runes) <= length {
+		return str
+	}
+
+	return string(runes[:length-1]) + "…"
+}
+
+// padString pads the string with spaces, counting runes instead of bytes so monikers with emojis or non-latin chars stay aligned.
+func padString(str string, length int) string {
+	if count := utf8.RuneCountInString(str); count < length {
+		return str + strings.Repeat(" ", length-count)
+	}
+
+	return str
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	cstypes "github.com/tendermint/tendermint/consensus/types"
+	tmrpc "github.com/tendermint/tendermint/rpc/client/http"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	ctypes "github.com/tendermint/tendermint/types"
+
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// ConsensusRoundVotes is the votes of a single round from the consensus_state endpoint.
+// Votes are ordered the same way as the validator set, the missing ones are "nil-Vote".
+type ConsensusRoundVotes struct {
+	Round      int32    `json:"round"`
+	Prevotes   []string `json:"prevotes"`
+	Precommits []string `json:"precommits"`
+}
+
+// ConsensusVotesStats is the voting power share and the validators that have not voted
+// for one of the vote types.
+type ConsensusVotesStats struct {
+	VotedShare float64
+	NotVoted   []*ctypes.Validator
+}
+
+const NilVote = "nil-Vote"
+
+func getConsensusState(message *tb.Message) {
+	// --------------------------------
+	var consensusState *coretypes.ResultConsensusState
+	err := tendermintClient.Query(func(ctx context.Context, client *tmrpc.HTTP) error {
+		var err error
+		consensusState, err = client.ConsensusState(ctx)
+		return err
+	})
+
+	if err != nil {
+		log.Error().Err(err).Msg("getConsensusState: Could not get consensus state")
+		sendMessage(message, "Could not get consensus state")
+		return
+	}
+
+	var roundState cstypes.RoundStateSimple
+	if err := json.Unmarshal(consensusState.RoundState, &roundState); err != nil {
+		log.Error().Err(err).Msg("getConsensusState: Could not parse consensus state")
+		sendMessage(message, "Could not parse consensus state")
+		return
+	}
+
+	var roundVotes []ConsensusRoundVotes
+	if err := json.Unmarshal(roundState.Votes, &roundVotes); err != nil {
+		log.Error().Err(err).Msg("getConsensusState: Could not parse consensus votes")
+		sendMessage(message, "Could not parse consensus state")
+		return
+	}
+
+	height, round, step, err := parseHeightRoundStep(roundState.HeightRoundStep)
+	if err != nil {
+		log.Error().Err(err).Str("value", roundState.HeightRoundStep).Msg("getConsensusState: Could not parse height/round/step")
+		sendMessage(message, "Could not parse consensus state")
+		return
+	}
+
+	var currentRoundVotes *ConsensusRoundVotes
+	for index := range roundVotes {
+		if roundVotes[index].Round == round {
+			currentRoundVotes = &roundVotes[index]
+		}
+	}
+
+	if currentRoundVotes == nil {
+		sendMessage(message, fmt.Sprintf("There are no votes for round %d yet", round))
+		return
+	}
+
+	tmValidators, err := getTendermintValidators(height)
+	if err != nil {
+		log.Error().Err(err).Msg("getConsensusState: Could not get Tendermint validators")
+		sendMessage(message, "Could not get validators")
+		return
+	}
+
+	validators, err := getValidatorsByConsAddress()
+	if err != nil {
+		log.Error().Err(err).Msg("getConsensusState: Could not get validators")
+	}
+
+	prevotes := getConsensusVotesStats(currentRoundVotes.Prevotes, tmValidators)
+	precommits := getConsensusVotesStats(currentRoundVotes.Precommits, tmValidators)
+
+	// --------------------------------
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<strong>Height: </strong><code>%d</code>\n", height))
+	sb.WriteString(fmt.Sprintf("<strong>Round: </strong><code>%d</code>\n", round))
+	sb.WriteString(fmt.Sprintf("<strong>Step: </strong><code>%s</code>\n", strings.TrimPrefix(step.String(), "RoundStep")))
+	sb.WriteString(fmt.Sprintf(
+		"<strong>Round started: </strong><code>%s</code> (<code>%s</code> ago)\n",
+		roundState.StartTime.Format(time.RFC822),
+		time.Since(roundState.StartTime).Round(time.Second).String(),
+	))
+	sb.WriteString(fmt.Sprintf("<strong>Prevotes: </strong><code>%.2f%%</code>\n", prevotes.VotedShare*100))
+	sb.WriteString(fmt.Sprintf("<strong>Precommits: </strong><code>%.2f%%</code>\n", precommits.VotedShare*100))
+
+	sb.WriteString(serializeNotVotedValidators("Not prevoted", prevotes.NotVoted, validators))
+	sb.WriteString(serializeNotVotedValidators("Not precommitted", precommits.NotVoted, validators))
+
+	for _, chunk := range splitMessage(sb.String()) {
+		sendMessage(message, chunk)
+	}
+
+	log.Info().
+		Int64("height", height).
+		Int32("round", round).
+		Str("user", message.Sender.Username).
+		Msg("Successfully returned consensus state")
+}
+
+// parseHeightRoundStep parses the "height/round/step" value of the consensus state, like "123/0/4".
+func parseHeightRoundStep(value string) (int64, int32, cstypes.RoundStepType, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) != 3 {
+		return 0, 0, 0, fmt.Errorf("expected height/round/step, got %s", value)
+	}
+
+	height, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	round, err := strconv.ParseInt(parts[1], 10, 32)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	step, err := strconv.ParseUint(parts[2], 10, 8)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	return height, int32(round), cstypes.RoundStepType(step), nil
+}
+
+// getTendermintValidators returns the validator set at the height, in the same order
+// the consensus votes are.
+func getTendermintValidators(height int64) ([]*ctypes.Validator, error) {
+	validators := []*ctypes.Validator{}
+	perPage := 100
+
+	for page := 1; ; page++ {
+		var result *coretypes.ResultValidators
+		err := tendermintClient.Query(func(ctx context.Context, client *tmrpc.HTTP) error {
+			var err error
+			result, err = client.Validators(ctx, &height, &page, &perPage)
+			return err
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		validators = append(validators, result.Validators...)
+		if len(validators) >= result.Total || len(result.Validators) == 0 {
+			return validators, nil
+		}
+	}
+}
+
+func getConsensusVotesStats(votes []string, validators []*ctypes.Validator) ConsensusVotesStats {
+	stats := ConsensusVotesStats{NotVoted: []*ctypes.Validator{}}
+	totalPower := int64(0)
+	votedPower := int64(0)
+
+	for index, validator := range validators {
+		totalPower += validator.VotingPower
+
+		if index < len(votes) && votes[index] != NilVote {
+			votedPower += validator.VotingPower
+		} else {
+			stats.NotVoted = append(stats.NotVoted, validator)
+		}
+	}
+
+	if totalPower > 0 {
+		stats.VotedShare = float64(votedPower) / float64(totalPower)
+	}
+
+	return stats
+}
+
+func serializeNotVotedValidators(
+	title string,
+	notVoted []*ctypes.Validator,
+	validators map[string]stakingtypes.Validator,
+) string {
+	if len(notVoted) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\n<strong>%s (%d):</strong>\n", title, len(notVoted)))
+
+	for _, tmValidator := range notVoted {
+		if validator, ok := validators[tmValidator.Address.String()]; ok {
+			sb.WriteString(fmt.Sprintf(
+				"- <a href=\"https://mintscan.io/%s/validators/%s\">%s</a>\n",
+				MintscanPrefix,
+				validator.OperatorAddress,
+				escapeHTML(validator.Description.Moniker),
+			))
+		} else {
+			sb.WriteString(fmt.Sprintf("- <code>%s</code>\n", tmValidator.Address.String()))
+		}
+	}
+
+	return sb.String()
+}
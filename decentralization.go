@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// DecentralizationSnapshotInterval is how often the metrics snapshot used for comparison is replaced.
+const DecentralizationSnapshotInterval = 24 * time.Hour
+
+type DecentralizationMetrics struct {
+	Time         time.Time `json:"time"`
+	Validators   int       `json:"validators"`
+	Nakamoto33   int       `json:"nakamoto_33"`
+	Nakamoto66   int       `json:"nakamoto_66"`
+	Gini         float64   `json:"gini"`
+	Top10Share   float64   `json:"top_10_share"`
+	Top20Share   float64   `json:"top_20_share"`
+	BondedTokens float64   `json:"bonded_tokens"`
+}
+
+func getDecentralization(message *tb.Message) {
+	// --------------------------------
+	validators, err := getSortedValidators()
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get validators")
+		sendMessage(message, "Could not get validators")
+		return
+	}
+
+	powers := []float64{}
+	for _, validator := range validators {
+		if !validator.IsBonded() {
+			continue
+		}
+
+		if value, err := intToFloat(validator.Tokens); err != nil {
+			log.Error().
+				Str("address", validator.OperatorAddress).
+				Err(err).
+				Msg("Could not parse validator tokens")
+			sendMessage(message, "Could not parse validator tokens")
+			return
+		} else {
+			powers = append(powers, value)
+		}
+	}
+
+	if len(powers) == 0 {
+		sendMessage(message, "There are no bonded validators")
+		return
+	}
+
+	metrics := calculateDecentralizationMetrics(powers)
+
+	stateMutex.Lock()
+	previous := state.DecentralizationSnapshot
+	if previous == nil || metrics.Time.Sub(previous.Time) >= DecentralizationSnapshotInterval {
+		state.DecentralizationSnapshot = &metrics
+		saveState()
+	}
+	stateMutex.Unlock()
+
+	// --------------------------------
+
+	var sb strings.Builder
+	sb.WriteString("<strong>Decentralization metrics</strong>\n\n")
+	sb.WriteString(fmt.Sprintf("<strong>Active validators: </strong><code>%d</code>\n", metrics.Validators))
+	sb.WriteString(Printer.Sprintf(
+		"<strong>Bonded tokens: </strong><code>%.0f %s</code>\n",
+		metrics.BondedTokens/DenomCoefficient,
+		Denom,
+	))
+	sb.WriteString(fmt.Sprintf("<strong>Nakamoto coefficient (33%%): </strong><code>%d</code>\n", metrics.Nakamoto33))
+	sb.WriteString(fmt.Sprintf("<strong>Nakamoto coefficient (66%%): </strong><code>%d</code>\n", metrics.Nakamoto66))
+	sb.WriteString(fmt.Sprintf("<strong>Gini coefficient: </strong><code>%.3f</code>\n", metrics.Gini))
+	sb.WriteString(fmt.Sprintf("<strong>Top 10 validators share: </strong><code>%.2f%%</code>\n", metrics.Top10Share*100))
+	sb.WriteString(fmt.Sprintf("<strong>Top 20 validators share: </strong><code>%.2f%%</code>\n", metrics.Top20Share*100))
+
+	if previous != nil && previous.Time.Before(metrics.Time) {
+		sb.WriteString(fmt.Sprintf("\n<strong>Changes since %s:</strong>\n", previous.Time.Format(time.RFC822)))
+		sb.WriteString(fmt.Sprintf("Active validators: <code>%+d</code>\n", metrics.Validators-previous.Validators))
+		sb.WriteString(fmt.Sprintf("Nakamoto coefficient (33%%): <code>%+d</code>\n", metrics.Nakamoto33-previous.Nakamoto33))
+		sb.WriteString(fmt.Sprintf("Nakamoto coefficient (66%%): <code>%+d</code>\n", metrics.Nakamoto66-previous.Nakamoto66))
+		sb.WriteString(fmt.Sprintf("Gini coefficient: <code>%+.3f</code>\n", metrics.Gini-previous.Gini))
+		sb.WriteString(fmt.Sprintf("Top 10 validators share: <code>%+.2f%%</code>\n", (metrics.Top10Share-previous.Top10Share)*100))
+		sb.WriteString(fmt.Sprintf("Top 20 validators share: <code>%+.2f%%</code>\n", (metrics.Top20Share-previous.Top20Share)*100))
+	}
+
+	sendMessage(message, sb.String())
+	log.Info().
+		Str("user", message.Sender.Username).
+		Msg("Successfully returned decentralization info")
+}
+
+func calculateDecentralizationMetrics(powers []float64) DecentralizationMetrics {
+	sorted := make([]float64, len(powers))
+	copy(sorted, powers)
+	sort.Sort(sort.Reverse(sort.Float64Slice(sorted)))
+
+	total := float64(0)
+	for _, power := range sorted {
+		total += power
+	}
+
+	metrics := DecentralizationMetrics{
+		Time:         time.Now(),
+		Validators:   len(sorted),
+		BondedTokens: total,
+		Nakamoto33:   getNakamotoCoefficient(sorted, total, 1.0/3),
+		Nakamoto66:   getNakamotoCoefficient(sorted, total, 2.0/3),
+		Top10Share:   getTopShare(sorted, total, 10),
+		Top20Share:   getTopShare(sorted, total, 20),
+	}
+
+	// Gini coefficient is calculated on the powers sorted ascending:
+	// G = 2 * sum(i * x_i) / (n * sum(x_i)) - (n + 1) / n, with i starting from 1
+	if total > 0 {
+		n := float64(len(sorted))
+		weightedSum := float64(0)
+		for index := range sorted {
+			weightedSum += float64(index+1) * sorted[len(sorted)-1-index]
+		}
+
+		metrics.Gini = 2*weightedSum/(n*total) - (n+1)/n
+	}
+
+	return metrics
+}
+
+// getNakamotoCoefficient returns the minimal amount of validators that together
+// have more than the threshold share of voting power. Expects powers to be sorted descending.
+func getNakamotoCoefficient(powers []float64, total float64, threshold float64) int {
+	sum := float64(0)
+	for index, power := range powers {
+		sum += power
+		if sum > total*threshold {
+			return index + 1
+		}
+	}
+
+	return len(powers)
+}
+
+func getTopShare(powers []float64, total float64, count int) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	sum := float64(0)
+	for index := 0; index < count && index < len(powers); index++ {
+		sum += powers[index]
+	}
+
+	return sum / total
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	querytypes "github.com/cosmos/cosmos-sdk/types/query"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+// MaxDepositorsShown is how many of the largest deposits are listed in /proposal,
+// so the message won't go over Telegram's message length limit.
+const MaxDepositorsShown = 10
+
+func getDepositParams() (govtypes.DepositParams, error) {
+	govClient := govtypes.NewQueryClient(grpcConn)
+	paramsResponse, err := govClient.Params(
+		context.Background(),
+		&govtypes.QueryParamsRequest{ParamsType: govtypes.ParamDeposit},
+	)
+
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get deposit params")
+		return govtypes.DepositParams{}, err
+	}
+
+	return paramsResponse.DepositParams, nil
+}
+
+// serializeProposalDeposits returns the deposits of a proposal in deposit period
+// and how much is left to deposit for it to go into voting.
+func serializeProposalDeposits(proposal govtypes.Proposal) (string, error) {
+	govClient := govtypes.NewQueryClient(grpcConn)
+	depositsResponse, err := govClient.Deposits(
+		context.Background(),
+		&govtypes.QueryDepositsRequest{
+			ProposalId: proposal.ProposalId,
+			Pagination: &querytypes.PageRequest{Limit: PaginationLimit},
+		},
+	)
+
+	if err != nil {
+		log.Error().
+			Uint64("id", proposal.ProposalId).
+			Err(err).
+			Msg("Could not get deposits")
+		return "", err
+	}
+
+	depositParams, err := getDepositParams()
+	if err != nil {
+		return "", err
+	}
+
+	bondDenom, err := getBondDenom()
+	if err != nil {
+		return "", err
+	}
+
+	remaining := sdk.NewCoins()
+	for _, coin := range depositParams.MinDeposit {
+		if needed := coin.Amount.Sub(proposal.TotalDeposit.AmountOf(coin.Denom)); needed.IsPositive() {
+			remaining = remaining.Add(sdk.NewCoin(coin.Denom, needed))
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<strong>Deposits:</strong>\n")
+	sb.WriteString(fmt.Sprintf("Total deposit:   %s\n", serializeCoins(proposal.TotalDeposit, bondDenom)))
+	sb.WriteString(fmt.Sprintf("Minimal deposit: %s\n", serializeCoins(depositParams.MinDeposit, bondDenom)))
+
+	if remaining.Empty() {
+		sb.WriteString("Minimal deposit is reached ✅\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("Remaining:       %s\n", serializeCoins(remaining, bondDenom)))
+	}
+
+	deposits := depositsResponse.Deposits
+	sort.SliceStable(deposits, func(i, j int) bool {
+		return deposits[i].Amount.AmountOf(bondDenom).GT(deposits[j].Amount.AmountOf(bondDenom))
+	})
+
+	if len(deposits) > 0 {
+		sb.WriteString("\n<strong>Depositors:</strong>\n")
+		for index, deposit := range deposits {
+			if index >= MaxDepositorsShown {
+				sb.WriteString(fmt.Sprintf("and %d more\n", len(deposits)-MaxDepositorsShown))
+				break
+			}
+
+			sb.WriteString(fmt.Sprintf(
+				"<a href=\"https://mintscan.io/%s/account/%s\">%s</a>: %s\n",
+				MintscanPrefix,
+				escapeHTML(deposit.Depositor),
+				escapeHTML(deposit.Depositor),
+				serializeCoins(deposit.Amount, bondDenom),
+			))
+		}
+	}
+
+	return sb.String(), nil
+}
@@ -1,4 +1,4 @@
-module main
+module cosmos-interacter
 
 go 1.16
 
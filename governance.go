@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+type ProposalSnapshot struct {
+	Status govtypes.ProposalStatus `json:"status"`
+	// lead times of voting end reminders that were already posted
+	RemindersSent []time.Duration `json:"reminders_sent,omitempty"`
+}
+
+func subscribeToGovernance(message *tb.Message) {
+	subscribeChat(message, &state.GovernanceSubscriptions, "governance updates", "unsubscribe_governance")
+}
+
+func unsubscribeFromGovernance(message *tb.Message) {
+	unsubscribeChat(message, &state.GovernanceSubscriptions, "governance updates")
+}
+
+func startGovernanceMonitor() {
+	for {
+		checkProposals()
+		time.Sleep(GovernanceMonitorInterval)
+	}
+}
+
+func checkProposals() {
+	proposals, err := getProposals(govtypes.StatusNil)
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get proposals for monitoring")
+		return
+	}
+
+	stateMutex.Lock()
+	previousSnapshots := state.ProposalSnapshots
+	chats := getAlertChats(state.GovernanceSubscriptions)
+	stateMutex.Unlock()
+
+	// on the first run there's nothing to compare with, so only remembering
+	// the existing proposals instead of announcing all of them
+	firstRun := previousSnapshots == nil
+
+	snapshots := make(map[uint64]ProposalSnapshot, len(proposals))
+	updates := []string{}
+
+	for _, proposal := range proposals {
+		previous, found := previousSnapshots[proposal.ProposalId]
+		snapshot := ProposalSnapshot{Status: proposal.Status}
+		if found && previous.Status == proposal.Status {
+			snapshot.RemindersSent = previous.RemindersSent
+		}
+
+		if !firstRun {
+			if update := getProposalUpdate(proposal, previous, found, &snapshot); update != "" {
+				updates = append(updates, update)
+			}
+		}
+
+		snapshots[proposal.ProposalId] = snapshot
+	}
+
+	// proposals that didn't get enough deposit are removed from the store once the deposit period ends
+	for id, previous := range previousSnapshots {
+		if _, found := snapshots[id]; !found && previous.Status == govtypes.StatusDepositPeriod {
+			updates = append(updates, fmt.Sprintf(
+				"<strong>Proposal #%d</strong> did not get enough deposit and was removed",
+				id,
+			))
+		}
+	}
+
+	for _, update := range updates {
+		for _, chat := range chats {
+			sendMessageToChat(chat, update)
+		}
+	}
+
+	stateMutex.Lock()
+	state.ProposalSnapshots = snapshots
+	saveState()
+	stateMutex.Unlock()
+
+	if len(updates) > 0 {
+		log.Info().
+			Int("updates", len(updates)).
+			Int("chats", len(chats)).
+			Msg("Sent governance updates")
+	}
+}
+
+// getProposalUpdate returns the message to post about the proposal if something
+// has happened to it since the last check, updating the snapshot with the reminders sent.
+func getProposalUpdate(
+	proposal govtypes.Proposal,
+	previous ProposalSnapshot,
+	found bool,
+	snapshot *ProposalSnapshot,
+) string {
+	if !found || previous.Status != proposal.Status {
+		switch proposal.Status {
+		case govtypes.StatusDepositPeriod:
+			return fmt.Sprintf(
+				"📝 New proposal in deposit period, deposit ends at <code>%s</code>\n\n%s",
+				proposal.DepositEndTime.Format(time.RFC822),
+				serializeProposalShort(proposal),
+			)
+		case govtypes.StatusVotingPeriod:
+			return fmt.Sprintf(
+				"🗳 Voting has started, voting ends at <code>%s</code>\n\n%s",
+				proposal.VotingEndTime.Format(time.RFC822),
+				serializeProposalShort(proposal),
+			)
+		case govtypes.StatusPassed, govtypes.StatusRejected, govtypes.StatusFailed:
+			if !found {
+				return ""
+			}
+
+			return fmt.Sprintf(
+				"🏁 Voting has finished\n\n%s\n\n%s",
+				serializeProposalShort(proposal),
+				serializeFinalTally(proposal.FinalTallyResult),
+			)
+		}
+
+		return ""
+	}
+
+	if proposal.Status != govtypes.StatusVotingPeriod {
+		return ""
+	}
+
+	timeLeft := time.Until(proposal.VotingEndTime)
+
+	remindersSent, ok := pickDueReminder(VotingReminders, snapshot.RemindersSent, timeLeft)
+	if !ok {
+		return ""
+	}
+
+	snapshot.RemindersSent = remindersSent
+
+	return fmt.Sprintf(
+		"⏰ Voting ends in <code>%s</code>, at <code>%s</code>\n\n%s",
+		timeLeft.Round(time.Minute).String(),
+		proposal.VotingEndTime.Format(time.RFC822),
+		serializeProposalShort(proposal),
+	)
+}
+
+func serializeFinalTally(tally govtypes.TallyResult) string {
+	yes := tally.Yes.ToDec()
+	no := tally.No.ToDec()
+	abstain := tally.Abstain.ToDec()
+	veto := tally.NoWithVeto.ToDec()
+	total := yes.Add(no).Add(abstain).Add(veto)
+
+	var sb strings.Builder
+	sb.WriteString("<strong>Final tally:</strong>\n")
+	sb.WriteString(fmt.Sprintf("Yes:          <code>%s</code>\n", formatShare(yes, total)))
+	sb.WriteString(fmt.Sprintf("No:           <code>%s</code>\n", formatShare(no, total)))
+	sb.WriteString(fmt.Sprintf("Abstain:      <code>%s</code>\n", formatShare(abstain, total)))
+	sb.WriteString(fmt.Sprintf("No with veto: <code>%s</code>", formatShare(veto, total)))
+
+	return sb.String()
+}
+
+// pickDueReminder checks whether any of the reminder lead times has passed and wasn't sent yet,
+// returning the sent list with all such lead times added. Only one reminder should be posted then,
+// so a bot that was down for a while won't post several reminders at once.
+func pickDueReminder(leadTimes, sent []time.Duration, timeLeft time.Duration) ([]time.Duration, bool) {
+	// copying, so the caller's list won't be modified until it decides to save the result
+	sent = append([]time.Duration{}, sent...)
+	due := false
+
+	for _, leadTime := range leadTimes {
+		if timeLeft > leadTime || isDurationInList(sent, leadTime) {
+			continue
+		}
+
+		sent = append(sent, leadTime)
+		due = true
+	}
+
+	return sent, due
+}
+
+func isDurationInList(list []time.Duration, value time.Duration) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+func getGovParams(message *tb.Message) {
+	// --------------------------------
+	depositParams, err := getDepositParams()
+	if err != nil {
+		sendMessage(message, "Could not get deposit params")
+		return
+	}
+
+	votingParams, err := getVotingParams()
+	if err != nil {
+		sendMessage(message, "Could not get voting params")
+		return
+	}
+
+	tallyParams, err := getTallyParams()
+	if err != nil {
+		sendMessage(message, "Could not get tally params")
+		return
+	}
+
+	bondDenom, err := getBondDenom()
+	if err != nil {
+		sendMessage(message, "Could not get bond denom")
+		return
+	}
+
+	// --------------------------------
+
+	var sb strings.Builder
+	sb.WriteString("<strong>Governance params</strong>\n\n")
+	sb.WriteString(fmt.Sprintf("<strong>Minimal deposit: </strong>%s\n", serializeCoins(depositParams.MinDeposit, bondDenom)))
+	sb.WriteString(fmt.Sprintf("<strong>Max deposit period: </strong><code>%s</code>\n", depositParams.MaxDepositPeriod.String()))
+	sb.WriteString(fmt.Sprintf("<strong>Voting period: </strong><code>%s</code>\n", votingParams.VotingPeriod.String()))
+	sb.WriteString(fmt.Sprintf("<strong>Quorum: </strong><code>%s</code>\n", formatDecAsPercent(tallyParams.Quorum)))
+	sb.WriteString(fmt.Sprintf("<strong>Pass threshold: </strong><code>%s</code>\n", formatDecAsPercent(tallyParams.Threshold)))
+	sb.WriteString(fmt.Sprintf("<strong>Veto threshold: </strong><code>%s</code>\n", formatDecAsPercent(tallyParams.VetoThreshold)))
+
+	sendMessage(message, sb.String())
+	log.Info().
+		Str("user", message.Sender.Username).
+		Msg("Successfully returned governance params")
+}
+
+func getVotingParams() (govtypes.VotingParams, error) {
+	govClient := govtypes.NewQueryClient(grpcConn)
+	paramsResponse, err := govClient.Params(
+		context.Background(),
+		&govtypes.QueryParamsRequest{ParamsType: govtypes.ParamVoting},
+	)
+
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get voting params")
+		return govtypes.VotingParams{}, err
+	}
+
+	return paramsResponse.VotingParams, nil
+}
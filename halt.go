@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// ChainHalt is the last block generated before the chain stopped producing blocks.
+type ChainHalt struct {
+	Height        int64     `json:"height"`
+	LastBlockTime time.Time `json:"last_block_time"`
+}
+
+func subscribeToHalts(message *tb.Message) {
+	subscribeChat(message, &state.HaltSubscriptions, "chain halt alerts", "unsubscribe_halt")
+}
+
+func unsubscribeFromHalts(message *tb.Message) {
+	unsubscribeChat(message, &state.HaltSubscriptions, "chain halt alerts")
+}
+
+func startHaltMonitor() {
+	for {
+		checkChainHalt()
+		time.Sleep(HaltMonitorInterval)
+	}
+}
+
+func checkChainHalt() {
+	latestBlock, err := getBlock(nil)
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get latest block for halt monitoring")
+		return
+	}
+
+	sinceLatestBlock := time.Since(latestBlock.Time)
+
+	stateMutex.Lock()
+	halt := state.ChainHalt
+	chats := getAlertChats(state.HaltSubscriptions)
+	stateMutex.Unlock()
+
+	var text string
+
+	if halt == nil && sinceLatestBlock > HaltThreshold {
+		halt = &ChainHalt{Height: latestBlock.Height, LastBlockTime: latestBlock.Time}
+
+		stateMutex.Lock()
+		state.ChainHalt = halt
+		saveState()
+		stateMutex.Unlock()
+
+		text = fmt.Sprintf(
+			"🚨 The chain seems to be halted: no new blocks for <code>%s</code>.\nThe latest block is #%d generated at <code>%s</code>.",
+			sinceLatestBlock.Round(time.Second).String(),
+			latestBlock.Height,
+			latestBlock.Time.Format(time.RFC822),
+		)
+	} else if halt != nil && latestBlock.Height > halt.Height {
+		// the first block after the halt shows how long the chain was down
+		recoveryHeight := halt.Height + 1
+		recoveryBlock, err := getBlock(&recoveryHeight)
+		if err != nil {
+			log.Error().Err(err).Msg("Could not get the first block after the halt")
+			recoveryBlock = latestBlock
+		}
+
+		stateMutex.Lock()
+		state.ChainHalt = nil
+		saveState()
+		stateMutex.Unlock()
+
+		text = fmt.Sprintf(
+			"✅ The chain is producing blocks again, block #%d was generated at <code>%s</code>.\nThe halt lasted <code>%s</code>.",
+			recoveryBlock.Height,
+			recoveryBlock.Time.Format(time.RFC822),
+			recoveryBlock.Time.Sub(halt.LastBlockTime).Round(time.Second).String(),
+		)
+	}
+
+	if text == "" {
+		return
+	}
+
+	for _, chat := range chats {
+		sendMessageToChat(chat, text)
+	}
+
+	log.Info().
+		Int64("height", latestBlock.Height).
+		Int("chats", len(chats)).
+		Msg("Sent chain halt update")
+}
+
+// getChainHalt returns the ongoing chain halt, or nil if the chain is producing blocks.
+func getChainHalt() *ChainHalt {
+	stateMutex.Lock()
+	defer stateMutex.Unlock()
+
+	return state.ChainHalt
+}
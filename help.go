@@ -14,10 +14,33 @@ func getHelp(message *tb.Message) {
 	sb.WriteString("Can understand the following commands:\n")
 	sb.WriteString("- /wallet &lt;wallet address&gt; - get the wallet info (balance, delegated amount, rewards etc.)\n")
 	sb.WriteString("- /validator &lt;validator address or name&gt; - get validator info\n")
+	sb.WriteString("- /compare &lt;validator&gt; &lt;validator&gt; [...] - compare validators side by side\n")
+	sb.WriteString("- /decentralization - get the Nakamoto and Gini coefficients and the top validators voting power share\n")
+	sb.WriteString("- /subscribe_validator &lt;validator address or name&gt; - get alerts in this chat when the validator gets jailed, leaves the active set, changes commission etc.\n")
+	sb.WriteString("- /unsubscribe_validator &lt;validator address or name&gt; - stop getting validator alerts in this chat\n")
+	sb.WriteString("- /subscribe_governance - get new proposals, voting reminders and voting results in this chat\n")
+	sb.WriteString("- /unsubscribe_governance - stop getting governance updates in this chat\n")
 	sb.WriteString("- /rate - get the Coingecko exchange rate to USD\n")
 	sb.WriteString("- /proposal &lt;proposal ID&gt; - get the proposal info\n")
-	sb.WriteString("- /proposals - proposals list\n")
-	sb.WriteString("- /wenblock &lt;block ID&gt; - gets the approximate block generation time (or the actual one, if the block was generated already)\n")
+	sb.WriteString("- /searchproposal &lt;text&gt; - search proposals by title and description\n")
+	sb.WriteString("- /govparams - get the governance deposit, voting and tally params\n")
+	sb.WriteString("- /novote &lt;proposal ID&gt; - list active validators that have not voted on the proposal yet\n")
+	sb.WriteString("- /proposals [all|deposit|voting|passed|rejected|failed] - proposals list, the ones in voting period by default\n")
+	sb.WriteString("- /status - get the node and chain status\n")
+	sb.WriteString("- /consensus - get the current consensus round state and the validators that have not voted yet\n")
+	sb.WriteString("- /subscribe_halt - get alerted in this chat when the chain stops producing blocks\n")
+	sb.WriteString("- /unsubscribe_halt - stop getting chain halt alerts in this chat\n")
+	sb.WriteString("- /block [block ID] - get the block info, the latest block by default\n")
+	sb.WriteString("- /tx &lt;hash&gt; - get the decoded transaction info\n")
+	sb.WriteString("- /blocktime [blocks] - get the block time stats and the slowest blocks over the latest blocks, 100 by default\n")
+	sb.WriteString("- /proposers [blocks] - get the amount of blocks each validator proposed compared to the expected one\n")
+	sb.WriteString("- /wenblock &lt;block ID or +blocks&gt; - gets the approximate block generation time (or the actual one, if the block was generated already)\n")
+	sb.WriteString("- /blockat &lt;date&gt; - gets the block height at the given date (or the estimated one, if the date is in the future)\n")
+	sb.WriteString("- /remindblock &lt;block ID or +blocks&gt; [warnings, like 1h,10m] - post a message when the chain reaches the block, lists the chat reminders if called without arguments\n")
+	sb.WriteString("- /unremindblock &lt;block ID&gt; - cancel the block reminder\n")
+	sb.WriteString("- /upgrade - get the scheduled upgrade info and its estimated time\n")
+	sb.WriteString("- /subscribe_upgrade - get upgrade countdowns in this chat\n")
+	sb.WriteString("- /unsubscribe_upgrade - stop getting upgrade countdowns in this chat\n")
 	sb.WriteString("- /help - display this message\n")
 	sb.WriteString("- /about - get info about this bot and its creators\n\n")
 	sb.WriteString("<strong>Useful links:</strong>\n")
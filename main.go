@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
@@ -15,6 +17,7 @@ import (
 	"golang.org/x/text/message"
 	"google.golang.org/grpc"
 
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 
@@ -24,7 +27,7 @@ import (
 var (
 	ConfigPath     string
 	NodeAddress    string
-	TendermintRpc  string
+	TendermintRpcs []string
 	LogLevel       string
 	MintscanPrefix string
 	NetworkName    string
@@ -49,8 +52,30 @@ var (
 
 	PaginationLimit uint64
 
+	StatePath                string
+	ValidatorMonitorInterval time.Duration
+	MissedBlocksThreshold    int64
+
+	GovParticipationProposals int
+	GovernanceMonitorInterval time.Duration
+	VotingReminders           []time.Duration
+	UpgradeMonitorInterval    time.Duration
+	UpgradeReminders          []time.Duration
+
+	BlockTimeWindows []int64
+
+	HaltMonitorInterval time.Duration
+	HaltThreshold       time.Duration
+
+	TendermintTimeout             time.Duration
+	TendermintHealthCheckInterval time.Duration
+
 	grpcConn *grpc.ClientConn
 
+	tendermintClient *TendermintClient
+
+	interfaceRegistry codectypes.InterfaceRegistry
+
 	log = zerolog.New(zerolog.ConsoleWriter{Out: os.Stdout}).With().Timestamp().Logger()
 
 	bot *tb.Bot
@@ -58,6 +83,9 @@ var (
 	Printer = message.NewPrinter(language.English)
 )
 
+// MaxMessageLength is the max length of a Telegram message.
+const MaxMessageLength = 4096
+
 var rootCmd = &cobra.Command{
 	Use:  "cosmos-interacter",
 	Long: "Get the wallets and validators info for Cosmos validators",
@@ -82,7 +110,7 @@ var rootCmd = &cobra.Command{
 		cmd.Flags().VisitAll(func(f *pflag.Flag) {
 			if !f.Changed && viper.IsSet(f.Name) {
 				val := viper.Get(f.Name)
-				if err := cmd.Flags().Set(f.Name, fmt.Sprintf("%v", val)); err != nil {
+				if err := cmd.Flags().Set(f.Name, configValueToString(val)); err != nil {
 					log.Fatal().Err(err).Msg("Could not set flag")
 				}
 			}
@@ -177,6 +205,22 @@ func setDenom() {
 	log.Fatal().Msg("Could not find the denom info")
 }
 
+// configValueToString converts the config value to the form the flag expects,
+// joining the lists with commas, as the slice flags do.
+func configValueToString(value interface{}) string {
+	list, ok := value.([]interface{})
+	if !ok {
+		return fmt.Sprintf("%v", value)
+	}
+
+	values := make([]string, len(list))
+	for index, item := range list {
+		values[index] = fmt.Sprintf("%v", item)
+	}
+
+	return strings.Join(values, ",")
+}
+
 func Execute(cmd *cobra.Command, args []string) {
 	logLevel, err := zerolog.ParseLevel(LogLevel)
 	if err != nil {
@@ -190,6 +234,12 @@ func Execute(cmd *cobra.Command, args []string) {
 	config.SetBech32PrefixForConsensusNode(ConsensusNodePrefix, ConsensusNodePubkeyPrefix)
 	config.Seal()
 
+	interfaceRegistry = getInterfaceRegistry()
+
+	if err := loadState(); err != nil {
+		log.Fatal().Err(err).Msg("Could not load state")
+	}
+
 	grpcConn, err = grpc.Dial(
 		NodeAddress,
 		grpc.WithInsecure(),
@@ -200,6 +250,11 @@ func Execute(cmd *cobra.Command, args []string) {
 
 	defer grpcConn.Close()
 
+	tendermintClient, err = NewTendermintClient(TendermintRpcs)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Could not create Tendermint client")
+	}
+
 	setDenom()
 
 	bot, err = tb.NewBot(tb.Settings{
@@ -214,13 +269,46 @@ func Execute(cmd *cobra.Command, args []string) {
 
 	bot.Handle("/wallet", getWalletInfo)
 	bot.Handle("/validator", getValidatorInfo)
+	bot.Handle("/compare", compareValidators)
+	bot.Handle("/decentralization", getDecentralization)
 	bot.Handle("/proposals", getProposalsInfo)
+	bot.Handle(&proposalsPageButton, getProposalsInfoPage)
 	bot.Handle("/proposal", getProposalInfo)
+	bot.Handle("/novote", getNotVotedValidators)
+	bot.Handle("/govparams", getGovParams)
+	bot.Handle("/searchproposal", searchProposals)
+	bot.Handle("/status", getStatus)
+	bot.Handle("/consensus", getConsensusState)
+	bot.Handle("/block", getBlockInfo)
+	bot.Handle("/tx", getTxInfo)
+	bot.Handle("/blocktime", getBlockTimeStats)
+	bot.Handle("/proposers", getProposersStats)
 	bot.Handle("/wenblock", getBlockApproximateDate)
+	bot.Handle("/blockat", getBlockAtDate)
+	bot.Handle("/remindblock", addBlockReminder)
+	bot.Handle("/unremindblock", removeBlockReminder)
+	bot.Handle("/upgrade", getUpgradeInfo)
+	bot.Handle("/subscribe_upgrade", subscribeToUpgrades)
+	bot.Handle("/unsubscribe_upgrade", unsubscribeFromUpgrades)
 	bot.Handle("/rate", getRate)
+	bot.Handle("/subscribe_validator", subscribeToValidator)
+	bot.Handle("/unsubscribe_validator", unsubscribeFromValidator)
+	bot.Handle("/subscribe_governance", subscribeToGovernance)
+	bot.Handle("/unsubscribe_governance", unsubscribeFromGovernance)
+	bot.Handle("/subscribe_halt", subscribeToHalts)
+	bot.Handle("/unsubscribe_halt", unsubscribeFromHalts)
 	bot.Handle("/help", getHelp)
 	bot.Handle("/start", getHelp)
 	bot.Handle("/about", getAbout)
+
+	go startTendermintHealthCheck()
+	go startValidatorMonitor()
+	go startGovernanceMonitor()
+	go startUpgradeMonitor()
+	go startBlockReminders()
+	go startBlockTimeUpdater()
+	go startHaltMonitor()
+
 	bot.Start()
 }
 
@@ -239,9 +327,80 @@ func sendMessage(message *tb.Message, text string) {
 	}
 }
 
+// splitMessage splits the text into several messages by lines, so each of them fits into
+// Telegram's message length limit. The formatting tags should not span several lines.
+// Lines that don't fit into a message on their own are cut into several ones.
+func splitMessage(text string) []string {
+	chunks := []string{}
+
+	var sb strings.Builder
+	for _, line := range strings.SplitAfter(text, "\n") {
+		for len(line) > MaxMessageLength {
+			if sb.Len() > 0 {
+				chunks = append(chunks, sb.String())
+				sb.Reset()
+			}
+
+			// not cutting in the middle of a multibyte character
+			cut := MaxMessageLength
+			for cut > 0 && !utf8.RuneStart(line[cut]) {
+				cut--
+			}
+
+			chunks = append(chunks, line[:cut])
+			line = line[cut:]
+		}
+
+		if sb.Len() > 0 && sb.Len()+len(line) > MaxMessageLength {
+			chunks = append(chunks, sb.String())
+			sb.Reset()
+		}
+
+		sb.WriteString(line)
+	}
+
+	if sb.Len() > 0 {
+		chunks = append(chunks, sb.String())
+	}
+
+	return chunks
+}
+
+func sendMessageWithMarkup(message *tb.Message, text string, markup *tb.ReplyMarkup) {
+	_, err := bot.Send(
+		message.Chat,
+		text,
+		&tb.SendOptions{
+			ParseMode:   tb.ModeHTML,
+			ReplyTo:     message,
+			ReplyMarkup: markup,
+		},
+	)
+
+	if err != nil {
+		log.Error().Err(err).Msg("Could not send Telegram message")
+	}
+}
+
+func sendMessageToChat(chatID int64, text string) {
+	_, err := bot.Send(
+		tb.ChatID(chatID),
+		text,
+		&tb.SendOptions{
+			ParseMode: tb.ModeHTML,
+		},
+	)
+
+	if err != nil {
+		log.Error().Err(err).Int64("chat", chatID).Msg("Could not send Telegram message")
+	}
+}
+
 func main() {
 	rootCmd.PersistentFlags().StringVar(&ConfigPath, "config", "", "Config file path")
-	rootCmd.PersistentFlags().StringVar(&TendermintRpc, "tendermint-rpc", "http://localhost:26657", "Tendermint RPC address")
+	rootCmd.PersistentFlags().StringSliceVar(&TendermintRpcs, "tendermint-rpc", []string{"http://localhost:26657"}, "Tendermint RPC addresses, the next ones are used as fallbacks")
+	rootCmd.PersistentFlags().DurationVar(&TendermintTimeout, "tendermint-timeout", 10*time.Second, "Timeout for a single Tendermint RPC request")
+	rootCmd.PersistentFlags().DurationVar(&TendermintHealthCheckInterval, "tendermint-health-check-interval", 30*time.Second, "How often to check the Tendermint RPC nodes health")
 	rootCmd.PersistentFlags().StringVar(&Denom, "denom", "", "Cosmos coin denom")
 	rootCmd.PersistentFlags().Float64Var(&DenomCoefficient, "denom-coefficient", 0, "Denom coefficient")
 	rootCmd.PersistentFlags().Uint64Var(&PaginationLimit, "pagination-limit", 1000, "Pagination limit")
@@ -252,6 +411,17 @@ func main() {
 	rootCmd.PersistentFlags().StringVar(&AscendexCurrency, "ascendex-currency", "", "Ascendex currency")
 	rootCmd.PersistentFlags().StringVar(&MxcCurrency, "mxc-currency", "", "MXC currency")
 	rootCmd.PersistentFlags().StringVar(&NetworkName, "network-name", "Persistence", "Network name for help")
+	rootCmd.PersistentFlags().StringVar(&StatePath, "state-path", "state.json", "Path to the file to store subscriptions and monitors state in")
+	rootCmd.PersistentFlags().DurationVar(&ValidatorMonitorInterval, "validator-monitor-interval", time.Minute, "How often to check the subscribed validators")
+	rootCmd.PersistentFlags().IntVar(&GovParticipationProposals, "gov-participation-proposals", 10, "How many latest finished proposals to check validator votes on")
+	rootCmd.PersistentFlags().DurationVar(&GovernanceMonitorInterval, "governance-monitor-interval", time.Minute, "How often to check for the proposals updates")
+	rootCmd.PersistentFlags().DurationSliceVar(&VotingReminders, "voting-reminders", []time.Duration{24 * time.Hour, time.Hour}, "How long before the voting end to post reminders")
+	rootCmd.PersistentFlags().DurationVar(&UpgradeMonitorInterval, "upgrade-monitor-interval", time.Minute, "How often to check for the scheduled upgrade")
+	rootCmd.PersistentFlags().DurationSliceVar(&UpgradeReminders, "upgrade-reminders", []time.Duration{24 * time.Hour, time.Hour, 10 * time.Minute}, "How long before the upgrade to post countdowns")
+	rootCmd.PersistentFlags().DurationVar(&HaltMonitorInterval, "halt-monitor-interval", 30*time.Second, "How often to check whether the chain is halted")
+	rootCmd.PersistentFlags().DurationVar(&HaltThreshold, "halt-threshold", 5*time.Minute, "How long without new blocks to consider the chain halted")
+	rootCmd.PersistentFlags().Int64SliceVar(&BlockTimeWindows, "block-time-windows", []int64{100, 1000, 10000}, "Amounts of the latest blocks to calculate the average block time over")
+	rootCmd.PersistentFlags().Int64Var(&MissedBlocksThreshold, "missed-blocks-threshold", 100, "Missed blocks counter value to alert on")
 
 	rootCmd.PersistentFlags().StringVar(&TelegramToken, "telegram-token", "", "Telegram bot token")
 	rootCmd.PersistentFlags().IntVar(&TelegramChat, "telegram-chat", 0, "Telegram chat or user ID")
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	markdownFencedCodeRegexp = regexp.MustCompile("(?s)```[a-zA-Z0-9]*\\n?(.*?)```")
+	markdownInlineCodeRegexp = regexp.MustCompile("`([^`\\n]+)`")
+	markdownLinkRegexp       = regexp.MustCompile(`\[([^\]\n]+)\]\((https?://[^)\s]+)\)`)
+	markdownHeadingRegexp    = regexp.MustCompile(`(?m)^#{1,6}[ \t]+(.+?)[ \t]*#*[ \t]*$`)
+	markdownListRegexp       = regexp.MustCompile(`(?m)^([ \t]*)[-*+][ \t]+`)
+	// emphasis has to start and end with a non-space character, so "2 * 3 * 4" stays as is
+	markdownBoldRegexp   = regexp.MustCompile(`\*\*([^*\s](?:[^*\n]*[^*\s])?)\*\*|__([^_\s](?:[^_\n]*[^_\s])?)__`)
+	markdownItalicRegexp = regexp.MustCompile(`(^|[^\w*])\*([^*\s](?:[^*\n]*[^*\s])?)\*([^\w*]|$)|(^|[^\w_])_([^_\s](?:[^_\n]*[^_\s])?)_([^\w_]|$)`)
+	placeholderRegexp    = regexp.MustCompile("\x00(\\d+)\x00")
+	emphasisTagRegexp    = regexp.MustCompile(`</?[bi]>`)
+)
+
+// escapeHTML escapes the chain-sourced or user-provided text, so it can be put into Telegram HTML message.
+func escapeHTML(text string) string {
+	return html.EscapeString(text)
+}
+
+// markdownToHTML converts the Markdown text (like proposal description) into the subset of HTML
+// Telegram supports, escaping everything else.
+func markdownToHTML(text string) string {
+	// a lot of proposals are submitted via CLI with the newlines escaped
+	text = strings.ReplaceAll(text, "\\n", "\n")
+	// the placeholders are wrapped in null characters, so the text itself shouldn't have any
+	text = strings.ReplaceAll(text, "\x00", "")
+
+	// code blocks are replaced with the placeholders, so their content won't be formatted
+	placeholders := []string{}
+	addPlaceholder := func(value string) string {
+		placeholders = append(placeholders, value)
+		return fmt.Sprintf("\x00%d\x00", len(placeholders)-1)
+	}
+
+	text = markdownFencedCodeRegexp.ReplaceAllStringFunc(text, func(match string) string {
+		content := markdownFencedCodeRegexp.FindStringSubmatch(match)[1]
+		return addPlaceholder("<pre>" + escapeHTML(strings.TrimRight(content, "\n")) + "</pre>")
+	})
+
+	text = markdownInlineCodeRegexp.ReplaceAllStringFunc(text, func(match string) string {
+		content := markdownInlineCodeRegexp.FindStringSubmatch(match)[1]
+		return addPlaceholder("<code>" + escapeHTML(content) + "</code>")
+	})
+
+	text = escapeHTML(text)
+
+	// links are replaced with the placeholders too, so the emphasis passes won't touch the URLs
+	text = markdownLinkRegexp.ReplaceAllStringFunc(text, func(match string) string {
+		submatches := markdownLinkRegexp.FindStringSubmatch(match)
+		return addPlaceholder(`<a href="` + submatches[2] + `">` + submatches[1] + `</a>`)
+	})
+
+	// headings are bold already, so the bold markers inside them are dropped to avoid nested tags
+	text = markdownHeadingRegexp.ReplaceAllStringFunc(text, func(match string) string {
+		content := markdownHeadingRegexp.FindStringSubmatch(match)[1]
+		return "<b>" + markdownBoldRegexp.ReplaceAllString(content, "$1$2") + "</b>"
+	})
+	text = markdownListRegexp.ReplaceAllString(text, "$1• ")
+
+	// overlapping markers like "_a **b_ c**" produce the wrongly nested tags Telegram refuses to parse,
+	// so leaving the emphasis as is then
+	withEmphasis := markdownBoldRegexp.ReplaceAllString(text, "<b>$1$2</b>")
+	withEmphasis = markdownItalicRegexp.ReplaceAllString(withEmphasis, "$1$4<i>$2$5</i>$3$6")
+	if areTagsBalanced(withEmphasis) {
+		text = withEmphasis
+	}
+
+	// link texts might contain code spans, which always have the lower index, as they are replaced first
+	for index := range placeholders {
+		placeholders[index] = restorePlaceholders(placeholders[index], placeholders[:index])
+	}
+
+	return restorePlaceholders(text, placeholders)
+}
+
+func restorePlaceholders(text string, placeholders []string) string {
+	return placeholderRegexp.ReplaceAllStringFunc(text, func(match string) string {
+		index, err := strconv.Atoi(placeholderRegexp.FindStringSubmatch(match)[1])
+		if err != nil || index >= len(placeholders) {
+			return ""
+		}
+
+		return placeholders[index]
+	})
+}
+
+// areTagsBalanced checks whether every bold and italic tag is closed in the reverse order it was opened.
+func areTagsBalanced(text string) bool {
+	opened := []string{}
+
+	for _, tag := range emphasisTagRegexp.FindAllString(text, -1) {
+		if !strings.HasPrefix(tag, "</") {
+			opened = append(opened, tag[1:])
+			continue
+		}
+
+		if len(opened) == 0 || opened[len(opened)-1] != tag[2:] {
+			return false
+		}
+
+		opened = opened[:len(opened)-1]
+	}
+
+	return len(opened) == 0
+}
+
+// truncateMarkdown cuts the text to the given amount of characters, trying not to cut in the middle
+// of a line, so the unfinished formatting won't mess the rest of the message up.
+func truncateMarkdown(text string, length int) (string, bool) {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text, false
+	}
+
+	truncated := string(runes[:length])
+	if index := strings.LastIndex(truncated, "\n"); index > len(truncated)/2 {
+		truncated = truncated[:index]
+	}
+
+	// an unclosed code block would swallow the rest of the description
+	if strings.Count(truncated, "```")%2 == 1 {
+		truncated = truncated[:strings.LastIndex(truncated, "```")]
+	}
+
+	return strings.TrimRight(truncated, " \n") + "…", true
+}
@@ -0,0 +1,120 @@
+package main
+
+import "testing"
+
+func TestMarkdownToHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "plain text is escaped",
+			input:    "a < b & c > d",
+			expected: "a &lt; b &amp; c &gt; d",
+		},
+		{
+			name:     "link",
+			input:    "[spec](https://example.com/spec)",
+			expected: `<a href="https://example.com/spec">spec</a>`,
+		},
+		{
+			name:     "link with underscores in URL",
+			input:    "[spec](https://x.com/_draft_/a)",
+			expected: `<a href="https://x.com/_draft_/a">spec</a>`,
+		},
+		{
+			name:     "link with double underscores in URL",
+			input:    "[x](https://x.com/__init__.py)",
+			expected: `<a href="https://x.com/__init__.py">x</a>`,
+		},
+		{
+			name:     "link with asterisks in URL",
+			input:    "see [x](https://x.com/*a*/b) and *this*",
+			expected: `see <a href="https://x.com/*a*/b">x</a> and <i>this</i>`,
+		},
+		{
+			name:     "lone asterisks",
+			input:    "2 * 3 * 4",
+			expected: "2 * 3 * 4",
+		},
+		{
+			name:     "lone underscores",
+			input:    "a _ b _ c",
+			expected: "a _ b _ c",
+		},
+		{
+			name:     "snake case is not emphasis",
+			input:    "max_deposit_period",
+			expected: "max_deposit_period",
+		},
+		{
+			name:     "bold and italic",
+			input:    "**bold** and *italic* and __bold__ and _italic_",
+			expected: "<b>bold</b> and <i>italic</i> and <b>bold</b> and <i>italic</i>",
+		},
+		{
+			name:     "heading",
+			input:    "## Summary ##\ntext",
+			expected: "<b>Summary</b>\ntext",
+		},
+		{
+			name:     "heading with bold",
+			input:    "# The **new** params",
+			expected: "<b>The new params</b>",
+		},
+		{
+			name:     "heading with italic",
+			input:    "# The *new* params",
+			expected: "<b>The <i>new</i> params</b>",
+		},
+		{
+			name:     "list",
+			input:    "- one\n* two",
+			expected: "• one\n• two",
+		},
+		{
+			name:     "inline code",
+			input:    "use `a_b_c <x>`",
+			expected: "use <code>a_b_c &lt;x&gt;</code>",
+		},
+		{
+			name:     "fenced code",
+			input:    "```json\n{\"a\": \"*b*\", \"c\": \"<d>\"}\n```",
+			expected: "<pre>{&#34;a&#34;: &#34;*b*&#34;, &#34;c&#34;: &#34;&lt;d&gt;&#34;}</pre>",
+		},
+		{
+			name:     "link with code in text",
+			input:    "[`spec`](https://x.com)",
+			expected: `<a href="https://x.com"><code>spec</code></a>`,
+		},
+		{
+			name:     "overlapping bold and italic",
+			input:    "_a **b_ c**",
+			expected: "_a **b_ c**",
+		},
+		{
+			name:     "null characters in text",
+			input:    "hello \x0099\x00",
+			expected: "hello 99",
+		},
+		{
+			name:     "null characters in code",
+			input:    "`\x000\x00`",
+			expected: "<code>0</code>",
+		},
+		{
+			name:     "escaped newlines",
+			input:    "line one\\nline two",
+			expected: "line one\nline two",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := markdownToHTML(test.input); actual != test.expected {
+				t.Errorf("markdownToHTML(%q):\nexpected %q\n     got %q", test.input, test.expected, actual)
+			}
+		})
+	}
+}
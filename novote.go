@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	querytypes "github.com/cosmos/cosmos-sdk/types/query"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+func getNotVotedValidators(message *tb.Message) {
+	args := strings.SplitAfterN(message.Text, " ", 2)
+	if len(args) < 2 {
+		log.Info().Msg("getNotVotedValidators: args length < 2")
+		sendMessage(message, "Usage: novote &lt;proposal ID&gt;")
+		return
+	}
+
+	id, err := strconv.ParseUint(strings.TrimSpace(args[1]), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Msg("Could not parse proposal ID")
+		sendMessage(message, "Proposal ID should be a number")
+		return
+	}
+
+	// --------------------------------
+	proposal, err := getProposal(id)
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get proposal")
+		sendMessage(message, "Could not find proposal")
+		return
+	}
+
+	// votes are only stored while the proposal is in voting period
+	if proposal.Status != govtypes.StatusVotingPeriod {
+		sendMessage(message, fmt.Sprintf("Proposal #%d is not in voting period", id))
+		return
+	}
+
+	voters, err := getProposalVoters(id)
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get proposal votes")
+		sendMessage(message, "Could not get proposal votes")
+		return
+	}
+
+	validators, err := getSortedValidators()
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get validators")
+		sendMessage(message, "Could not get validators")
+		return
+	}
+
+	notVoted := []stakingtypes.Validator{}
+	bondedTokens := float64(0)
+	notVotedTokens := float64(0)
+
+	for _, validator := range validators {
+		if !validator.IsBonded() {
+			continue
+		}
+
+		tokens, err := intToFloat(validator.Tokens)
+		if err != nil {
+			log.Error().
+				Str("address", validator.OperatorAddress).
+				Err(err).
+				Msg("Could not parse validator tokens")
+			sendMessage(message, "Could not parse validator tokens")
+			return
+		}
+
+		bondedTokens += tokens
+
+		voter, err := getValidatorAccountAddress(validator)
+		if err != nil {
+			log.Error().
+				Str("address", validator.OperatorAddress).
+				Err(err).
+				Msg("Could not get validator account address")
+			continue
+		}
+
+		if _, voted := voters[voter]; !voted {
+			notVoted = append(notVoted, validator)
+			notVotedTokens += tokens
+		}
+	}
+
+	sort.SliceStable(notVoted, func(i, j int) bool {
+		return notVoted[i].Tokens.GT(notVoted[j].Tokens)
+	})
+
+	// --------------------------------
+
+	if len(notVoted) == 0 {
+		sendMessage(message, fmt.Sprintf("All active validators have voted on proposal #%d 🎉", id))
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<strong>Validators that have not voted on proposal #%d</strong>\n", id))
+	sb.WriteString(fmt.Sprintf(
+		"<code>%d</code> validators with <code>%.2f%%</code> of voting power\n\n",
+		len(notVoted),
+		notVotedTokens/bondedTokens*100,
+	))
+
+	for index, validator := range notVoted {
+		tokens, _ := intToFloat(validator.Tokens)
+		sb.WriteString(fmt.Sprintf(
+			"%d. <a href=\"https://mintscan.io/%s/validators/%s\">%s</a> - <code>%.2f%%</code>\n",
+			index+1,
+			MintscanPrefix,
+			validator.OperatorAddress,
+			escapeHTML(validator.Description.Moniker),
+			tokens/bondedTokens*100,
+		))
+	}
+
+	for _, chunk := range splitMessage(sb.String()) {
+		sendMessage(message, chunk)
+	}
+
+	log.Info().
+		Uint64("id", id).
+		Str("user", message.Sender.Username).
+		Msg("Successfully returned not voted validators")
+}
+
+// getProposalVoters returns the set of addresses that have voted on the proposal.
+func getProposalVoters(id uint64) (map[string]bool, error) {
+	govClient := govtypes.NewQueryClient(grpcConn)
+	voters := make(map[string]bool)
+
+	var nextKey []byte
+	for {
+		votesResponse, err := govClient.Votes(
+			context.Background(),
+			&govtypes.QueryVotesRequest{
+				ProposalId: id,
+				Pagination: &querytypes.PageRequest{Key: nextKey, Limit: PaginationLimit},
+			},
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, vote := range votesResponse.Votes {
+			voters[vote.Voter] = true
+		}
+
+		if votesResponse.Pagination == nil || len(votesResponse.Pagination.NextKey) == 0 {
+			return voters, nil
+		}
+
+		nextKey = votesResponse.Pagination.NextKey
+	}
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+
+	paramstypes "github.com/cosmos/cosmos-sdk/x/params/types/proposal"
+)
+
+// getParamValue returns the current raw JSON value of a module param.
+func getParamValue(subspace string, key string) (string, error) {
+	paramsClient := paramstypes.NewQueryClient(grpcConn)
+	paramsResponse, err := paramsClient.Params(
+		context.Background(),
+		&paramstypes.QueryParamsRequest{Subspace: subspace, Key: key},
+	)
+
+	if err != nil {
+		log.Error().
+			Str("subspace", subspace).
+			Str("key", key).
+			Err(err).
+			Msg("Could not get param value")
+		return "", err
+	}
+
+	return paramsResponse.Param.Value, nil
+}
@@ -12,6 +12,10 @@ import (
 	tb "gopkg.in/tucnak/telebot.v2"
 )
 
+// ProposalDescriptionMaxLength is how many characters of the description are shown in /proposal,
+// so the message won't go over Telegram's message length limit.
+const ProposalDescriptionMaxLength = 2000
+
 func getProposalInfo(message *tb.Message) {
 	args := strings.SplitAfterN(message.Text, " ", 2)
 	if len(args) < 2 {
@@ -47,8 +51,9 @@ func getProposalInfo(message *tb.Message) {
 			Str("user", message.Sender.Username).
 			Msg("Successfully returned proposal info")
 	} else {
-		sendMessage(message, serializedProposal)
-
+		for _, chunk := range splitMessage(serializedProposal) {
+			sendMessage(message, chunk)
+		}
 	}
 }
 
@@ -60,7 +65,8 @@ func serializeProposal(proposal govtypes.Proposal) (string, error) {
 
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("<strong>Proposal #%d</strong>\n", proposal.ProposalId))
-	sb.WriteString(fmt.Sprintf("<code>%s</code>\n", proposalInfo.Title))
+	sb.WriteString(fmt.Sprintf("<code>%s</code>\n", escapeHTML(proposalInfo.Title)))
+	sb.WriteString(fmt.Sprintf("Type:          <code>%s</code>\n", escapeHTML(proposalInfo.Type)))
 	sb.WriteString(fmt.Sprintf("Submit time:   <code>%s</code>\n", proposal.SubmitTime.Format(time.RFC822)))
 	sb.WriteString(fmt.Sprintf("Deposit time:  <code>%s</code>\n", proposal.DepositEndTime.Format(time.RFC822)))
 	sb.WriteString(fmt.Sprintf("Voting starts: <code>%s</code>\n", proposal.VotingStartTime.Format(time.RFC822)))
@@ -68,7 +74,43 @@ func serializeProposal(proposal govtypes.Proposal) (string, error) {
 	sb.WriteString(fmt.Sprintf("Status: <code>%s</code>\n", proposal.Status))
 	sb.WriteString(fmt.Sprintf("<a href=\"https://mintscan.io/%s/proposals/%d\">Mintscan</a>\n\n", MintscanPrefix, proposal.ProposalId))
 
-	sb.WriteString(fmt.Sprintf("<pre>%s</pre>", proposalInfo.Description))
+	if proposalInfo.Content != nil {
+		if details := getProposalDetails(proposalInfo.Content, proposal.Status); details != "" {
+			sb.WriteString(details + "\n")
+		}
+	}
+
+	if proposal.Status == govtypes.StatusDepositPeriod {
+		if deposits, err := serializeProposalDeposits(proposal); err != nil {
+			log.Error().
+				Uint64("id", proposal.ProposalId).
+				Err(err).
+				Msg("Could not get proposal deposits")
+		} else {
+			sb.WriteString(deposits + "\n")
+		}
+	}
+
+	if proposal.Status == govtypes.StatusVotingPeriod {
+		if tally, err := serializeProposalTally(proposal); err != nil {
+			log.Error().
+				Uint64("id", proposal.ProposalId).
+				Err(err).
+				Msg("Could not get proposal tally")
+		} else {
+			sb.WriteString(tally + "\n")
+		}
+	}
+
+	description, truncated := truncateMarkdown(proposalInfo.Description, ProposalDescriptionMaxLength)
+	sb.WriteString(markdownToHTML(description))
+	if truncated {
+		sb.WriteString(fmt.Sprintf(
+			"\n\n<a href=\"https://mintscan.io/%s/proposals/%d\">Read more on Mintscan</a>",
+			MintscanPrefix,
+			proposal.ProposalId,
+		))
+	}
 
 	return sb.String(), nil
 }
@@ -3,16 +3,47 @@ package main
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
+	querytypes "github.com/cosmos/cosmos-sdk/types/query"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 
 	tb "gopkg.in/tucnak/telebot.v2"
 )
 
+// ProposalsPerPage is how many proposals are shown in a single /proposals message,
+// so it won't go over Telegram's message length limit.
+const ProposalsPerPage = 5
+
+var proposalsPageButton = tb.InlineButton{Unique: "proposals_page"}
+
+var proposalStatusFilters = map[string]govtypes.ProposalStatus{
+	"all":      govtypes.StatusNil,
+	"deposit":  govtypes.StatusDepositPeriod,
+	"voting":   govtypes.StatusVotingPeriod,
+	"passed":   govtypes.StatusPassed,
+	"rejected": govtypes.StatusRejected,
+	"failed":   govtypes.StatusFailed,
+}
+
 func getProposalsInfo(message *tb.Message) {
+	filter := "voting"
+
+	args := strings.Fields(message.Text)
+	if len(args) >= 2 {
+		filter = strings.ToLower(args[1])
+	}
+
+	if _, ok := proposalStatusFilters[filter]; !ok {
+		log.Info().Str("filter", filter).Msg("getProposalsInfo: unknown status filter")
+		sendMessage(message, "Usage: proposals [all|deposit|voting|passed|rejected|failed]")
+		return
+	}
+
 	// --------------------------------
-	proposals, err := getProposals()
+	text, markup, err := getProposalsPage(filter, 0)
 	if err != nil {
 		log.Error().Err(err).Msg("Could not get proposals")
 		sendMessage(message, "Could not get proposals")
@@ -21,15 +52,118 @@ func getProposalsInfo(message *tb.Message) {
 
 	// --------------------------------
 
+	sendMessageWithMarkup(message, text, markup)
+	log.Info().
+		Str("filter", filter).
+		Str("user", message.Sender.Username).
+		Msg("Successfully returned proposals info")
+}
+
+func getProposalsInfoPage(callback *tb.Callback) {
+	// callback data is "<filter>|<page>"
+	args := strings.Split(callback.Data, "|")
+	if len(args) != 2 {
+		log.Error().Str("data", callback.Data).Msg("Invalid proposals page callback data")
+		return
+	}
+
+	filter := args[0]
+	page, err := strconv.Atoi(args[1])
+	if err != nil {
+		log.Error().Err(err).Str("data", callback.Data).Msg("Could not parse proposals page")
+		return
+	}
+
+	text, markup, err := getProposalsPage(filter, page)
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get proposals")
+		if err := bot.Respond(callback, &tb.CallbackResponse{Text: "Could not get proposals"}); err != nil {
+			log.Error().Err(err).Msg("Could not respond to callback")
+		}
+		return
+	}
+
+	if _, err := bot.Edit(callback.Message, text, &tb.SendOptions{
+		ParseMode:   tb.ModeHTML,
+		ReplyMarkup: markup,
+	}); err != nil {
+		log.Error().Err(err).Msg("Could not edit proposals message")
+	}
+
+	if err := bot.Respond(callback); err != nil {
+		log.Error().Err(err).Msg("Could not respond to callback")
+	}
+
+	log.Info().
+		Str("filter", filter).
+		Int("page", page).
+		Str("user", callback.Sender.Username).
+		Msg("Successfully returned proposals page")
+}
+
+// getProposalsPage returns the page of proposals with the given status, newest first,
+// along with the buttons to navigate to the neighbour pages.
+func getProposalsPage(filter string, page int) (string, *tb.ReplyMarkup, error) {
+	status, ok := proposalStatusFilters[filter]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown proposal status filter: %s", filter)
+	}
+
+	proposals, err := getProposals(status)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(proposals) == 0 {
+		return fmt.Sprintf("There are no proposals matching filter <code>%s</code>", filter), nil, nil
+	}
+
+	sort.Slice(proposals, func(i, j int) bool {
+		return proposals[i].ProposalId > proposals[j].ProposalId
+	})
+
+	pagesCount := (len(proposals) + ProposalsPerPage - 1) / ProposalsPerPage
+	if page < 0 {
+		page = 0
+	} else if page >= pagesCount {
+		page = pagesCount - 1
+	}
+
+	from := page * ProposalsPerPage
+	to := from + ProposalsPerPage
+	if to > len(proposals) {
+		to = len(proposals)
+	}
+
 	var sb strings.Builder
-	for _, proposal := range proposals {
+	sb.WriteString(fmt.Sprintf(
+		"<strong>Proposals (%s), page %d of %d</strong>\n\n",
+		filter,
+		page+1,
+		pagesCount,
+	))
+	for _, proposal := range proposals[from:to] {
 		sb.WriteString(serializeProposalShort(proposal) + "\n\n")
 	}
 
-	sendMessage(message, sb.String())
-	log.Info().
-		Str("user", message.Sender.Username).
-		Msg("Successfully returned proposals info")
+	buttons := []tb.InlineButton{}
+	if page > 0 {
+		button := *proposalsPageButton.With(fmt.Sprintf("%s|%d", filter, page-1))
+		button.Text = "⬅️ Newer"
+		buttons = append(buttons, button)
+	}
+
+	if page < pagesCount-1 {
+		button := *proposalsPageButton.With(fmt.Sprintf("%s|%d", filter, page+1))
+		button.Text = "Older ➡️"
+		buttons = append(buttons, button)
+	}
+
+	if len(buttons) == 0 {
+		return sb.String(), nil, nil
+	}
+
+	return sb.String(), &tb.ReplyMarkup{InlineKeyboard: [][]tb.InlineButton{buttons}}, nil
 }
 
 func serializeProposalShort(proposal govtypes.Proposal) string {
@@ -42,7 +176,7 @@ func serializeProposalShort(proposal govtypes.Proposal) string {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("<strong>Proposal #%d</strong>\n", proposal.ProposalId))
 	if proposalInfo.Title != "" {
-		sb.WriteString(fmt.Sprintf("<code>%s</code>\n", proposalInfo.Title))
+		sb.WriteString(fmt.Sprintf("<code>%s</code>\n", escapeHTML(proposalInfo.Title)))
 	}
 	sb.WriteString(fmt.Sprintf("Status: <code>%s</code>\n", proposal.Status))
 	sb.WriteString(fmt.Sprintf("<a href=\"https://mintscan.io/%s/proposals/%d\">Mintscan</a>\n", MintscanPrefix, proposal.ProposalId))
@@ -51,11 +185,15 @@ func serializeProposalShort(proposal govtypes.Proposal) string {
 	return sb.String()
 }
 
-func getProposals() (govtypes.Proposals, error) {
+// getProposals returns the proposals with the given status, or all of them if the status is govtypes.StatusNil.
+func getProposals(status govtypes.ProposalStatus) (govtypes.Proposals, error) {
 	govClient := govtypes.NewQueryClient(grpcConn)
 	proposalResponse, err := govClient.Proposals(
 		context.Background(),
-		&govtypes.QueryProposalsRequest{},
+		&govtypes.QueryProposalsRequest{
+			ProposalStatus: status,
+			Pagination:     &querytypes.PageRequest{Limit: PaginationLimit},
+		},
 	)
 
 	if err != nil {
@@ -0,0 +1,411 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tmrpc "github.com/tendermint/tendermint/rpc/client/http"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	ctypes "github.com/tendermint/tendermint/types"
+
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// BlockReminder is a chat's request to be notified when the chain reaches the height,
+// with optional warnings posted at the estimated times before it.
+type BlockReminder struct {
+	ChatID       int64           `json:"chat_id"`
+	Height       int64           `json:"height"`
+	Warnings     []time.Duration `json:"warnings,omitempty"`
+	WarningsSent []time.Duration `json:"warnings_sent,omitempty"`
+}
+
+const (
+	// how often to recalculate the average block time used for the warnings
+	BlockReminderBlockTimeCacheDuration = 10 * time.Minute
+	// resubscribing if there are no new blocks for this long, as the node might be stuck
+	BlockSubscriptionTimeout = 5 * time.Minute
+	// how many received blocks can wait for the reminders to be checked
+	BlockRemindersQueueSize = 100
+)
+
+var (
+	// the average block time in seconds used to estimate when to post the warnings,
+	// kept up to date by startBlockTimeUpdater
+	reminderBlockTime      float64
+	reminderBlockTimeMutex sync.Mutex
+
+	// the blocks are checked separately from the subscription, so slow Telegram requests
+	// won't stop the websocket events from being read
+	blockRemindersQueue = make(chan *ctypes.Block, BlockRemindersQueueSize)
+)
+
+// blockReminderKey identifies the reminder, as a chat can only have one reminder per block.
+type blockReminderKey struct {
+	ChatID int64
+	Height int64
+}
+
+func addBlockReminder(message *tb.Message) {
+	args := strings.Fields(message.Text)
+	if len(args) < 2 {
+		listBlockReminders(message)
+		return
+	}
+
+	latestBlock, err := getBlock(nil)
+	if err != nil {
+		log.Error().Err(err).Msg("addBlockReminder: Could not get latest block")
+		sendMessage(message, "Could not get block info")
+		return
+	}
+
+	height, err := strconv.ParseInt(strings.TrimPrefix(args[1], "+"), 10, 64)
+	if err != nil {
+		sendMessage(message, "Block should be a number!")
+		return
+	}
+
+	if strings.HasPrefix(args[1], "+") {
+		height += latestBlock.Height
+	}
+
+	if height <= latestBlock.Height {
+		sendMessage(message, fmt.Sprintf("Block #%d has already been generated", height))
+		return
+	}
+
+	warnings := []time.Duration{}
+	for _, arg := range args[2:] {
+		for _, value := range strings.Split(arg, ",") {
+			if value == "" {
+				continue
+			}
+
+			warning, err := time.ParseDuration(value)
+			if err != nil || warning <= 0 {
+				sendMessage(message, fmt.Sprintf("Could not parse warning time: <code>%s</code>", escapeHTML(value)))
+				return
+			}
+
+			warnings = append(warnings, warning)
+		}
+	}
+
+	stateMutex.Lock()
+	for _, reminder := range state.BlockReminders {
+		if reminder.ChatID == message.Chat.ID && reminder.Height == height {
+			stateMutex.Unlock()
+			sendMessage(message, fmt.Sprintf("This chat already has a reminder for block #%d", height))
+			return
+		}
+	}
+
+	state.BlockReminders = append(state.BlockReminders, BlockReminder{
+		ChatID:   message.Chat.ID,
+		Height:   height,
+		Warnings: warnings,
+	})
+	saveState()
+	stateMutex.Unlock()
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Will post a message when the chain reaches block #%d", height))
+	if estimatedTime, err := getEstimatedBlockTime(latestBlock, height); err == nil {
+		sb.WriteString(fmt.Sprintf(", estimated at <code>%s</code>", estimatedTime.Format(time.RFC822)))
+	}
+	sb.WriteString(".\n")
+
+	if len(warnings) > 0 {
+		serializedWarnings := make([]string, len(warnings))
+		for index, warning := range warnings {
+			serializedWarnings[index] = fmt.Sprintf("<code>%s</code>", warning.String())
+		}
+
+		sb.WriteString(fmt.Sprintf("Warnings will be posted %s before it.\n", strings.Join(serializedWarnings, ", ")))
+	}
+
+	sb.WriteString(fmt.Sprintf("Use /unremindblock %d to cancel.", height))
+
+	sendMessage(message, sb.String())
+	log.Info().
+		Int64("chat", message.Chat.ID).
+		Int64("height", height).
+		Str("user", message.Sender.Username).
+		Msg("Successfully added block reminder")
+}
+
+func listBlockReminders(message *tb.Message) {
+	stateMutex.Lock()
+	heights := []int64{}
+	for _, reminder := range state.BlockReminders {
+		if reminder.ChatID == message.Chat.ID {
+			heights = append(heights, reminder.Height)
+		}
+	}
+	stateMutex.Unlock()
+
+	if len(heights) == 0 {
+		sendMessage(message, "Usage: remindblock &lt;block height or +blocks&gt; [warnings, like 1h,10m]")
+		return
+	}
+
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] < heights[j]
+	})
+
+	var sb strings.Builder
+	sb.WriteString("<strong>Block reminders in this chat:</strong>\n")
+	for _, height := range heights {
+		sb.WriteString(fmt.Sprintf("- <code>%d</code>\n", height))
+	}
+
+	sendMessage(message, sb.String())
+}
+
+func removeBlockReminder(message *tb.Message) {
+	args := strings.Fields(message.Text)
+	if len(args) < 2 {
+		sendMessage(message, "Usage: unremindblock &lt;block height&gt;")
+		return
+	}
+
+	height, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		sendMessage(message, "Block should be a number!")
+		return
+	}
+
+	stateMutex.Lock()
+	removed := false
+	for index, reminder := range state.BlockReminders {
+		if reminder.ChatID == message.Chat.ID && reminder.Height == height {
+			state.BlockReminders = append(state.BlockReminders[:index], state.BlockReminders[index+1:]...)
+			removed = true
+			break
+		}
+	}
+
+	if removed {
+		saveState()
+	}
+	stateMutex.Unlock()
+
+	if !removed {
+		sendMessage(message, fmt.Sprintf("This chat has no reminder for block #%d", height))
+		return
+	}
+
+	sendMessage(message, fmt.Sprintf("Removed the reminder for block #%d", height))
+	log.Info().
+		Int64("chat", message.Chat.ID).
+		Int64("height", height).
+		Str("user", message.Sender.Username).
+		Msg("Successfully removed block reminder")
+}
+
+// startBlockReminders listens for the new blocks over the Tendermint websocket
+// and reconnects to the current healthy node if the subscription drops.
+func startBlockReminders() {
+	go processBlockReminders()
+
+	for {
+		if err := listenForBlocks(); err != nil {
+			log.Error().Err(err).Msg("Block subscription failed")
+		}
+
+		time.Sleep(TendermintHealthCheckInterval)
+	}
+}
+
+func listenForBlocks() error {
+	address := tendermintClient.GetCurrentNode().Address
+
+	client, err := tmrpc.New(address, "/websocket")
+	if err != nil {
+		return err
+	}
+
+	if err := client.Start(); err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := client.Stop(); err != nil {
+			log.Error().Err(err).Msg("Could not stop Tendermint websocket client")
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TendermintTimeout)
+	events, err := client.Subscribe(ctx, "block-reminders", ctypes.EventQueryNewBlock.String())
+	cancel()
+
+	if err != nil {
+		return err
+	}
+
+	log.Info().Str("node", address).Msg("Subscribed to new blocks")
+
+	timeout := time.NewTimer(BlockSubscriptionTimeout)
+	defer timeout.Stop()
+
+	for {
+		var event coretypes.ResultEvent
+		select {
+		case received, open := <-events:
+			if !open {
+				return fmt.Errorf("block subscription closed")
+			}
+
+			event = received
+		case <-timeout.C:
+			return fmt.Errorf("no new blocks for %s", BlockSubscriptionTimeout)
+		}
+
+		// the timer has not fired yet if it's stopped successfully, otherwise draining it before reusing
+		if !timeout.Stop() {
+			<-timeout.C
+		}
+		timeout.Reset(BlockSubscriptionTimeout)
+
+		data, ok := event.Data.(ctypes.EventDataNewBlock)
+		if !ok || data.Block == nil {
+			continue
+		}
+
+		select {
+		case blockRemindersQueue <- data.Block:
+		default:
+			// the next block checks all of the reminders anyway, so it's fine to skip this one
+			log.Warn().Int64("height", data.Block.Height).Msg("Block reminders queue is full, skipping block")
+		}
+	}
+}
+
+// processBlockReminders checks the reminders for the received blocks one by one,
+// so the same reminder won't be posted twice.
+func processBlockReminders() {
+	for block := range blockRemindersQueue {
+		reminderBlockTimeMutex.Lock()
+		avgBlockTime := reminderBlockTime
+		reminderBlockTimeMutex.Unlock()
+
+		checkBlockReminders(block, avgBlockTime)
+	}
+}
+
+// startBlockTimeUpdater recalculates the average block time for the block reminders
+// separately from the blocks subscription, as it takes quite a few requests.
+func startBlockTimeUpdater() {
+	for {
+		updateReminderBlockTime()
+		time.Sleep(BlockReminderBlockTimeCacheDuration)
+	}
+}
+
+func updateReminderBlockTime() {
+	latestBlock, err := getBlock(nil)
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get latest block for block reminders")
+		return
+	}
+
+	avgBlockTime, err := getAverageBlockTime(latestBlock)
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get average block time for block reminders")
+		return
+	}
+
+	reminderBlockTimeMutex.Lock()
+	reminderBlockTime = avgBlockTime
+	reminderBlockTimeMutex.Unlock()
+}
+
+func checkBlockReminders(block *ctypes.Block, avgBlockTime float64) {
+	type notification struct {
+		chatID int64
+		text   string
+	}
+
+	notifications := []notification{}
+	reached := map[blockReminderKey]bool{}
+	warningsSent := map[blockReminderKey][]time.Duration{}
+
+	stateMutex.Lock()
+	reminders := make([]BlockReminder, len(state.BlockReminders))
+	copy(reminders, state.BlockReminders)
+	stateMutex.Unlock()
+
+	for _, reminder := range reminders {
+		key := blockReminderKey{ChatID: reminder.ChatID, Height: reminder.Height}
+
+		if block.Height >= reminder.Height {
+			notifications = append(notifications, notification{
+				chatID: reminder.ChatID,
+				text: fmt.Sprintf(
+					"🏁 The chain has reached block #%d at <code>%s</code>",
+					reminder.Height,
+					block.Time.Format(time.RFC822),
+				),
+			})
+			reached[key] = true
+			continue
+		}
+
+		if avgBlockTime > 0 {
+			timeLeft := time.Duration(avgBlockTime * float64(reminder.Height-block.Height) * float64(time.Second))
+
+			if sent, due := pickDueReminder(reminder.Warnings, reminder.WarningsSent, timeLeft); due {
+				warningsSent[key] = sent
+				notifications = append(notifications, notification{
+					chatID: reminder.ChatID,
+					text: fmt.Sprintf(
+						"⏰ Block #%d is coming in about <code>%s</code>, at <code>%s</code>",
+						reminder.Height,
+						timeLeft.Round(time.Minute).String(),
+						block.Time.Add(timeLeft).Format(time.RFC822),
+					),
+				})
+			}
+		}
+	}
+
+	if len(notifications) == 0 {
+		return
+	}
+
+	for _, notification := range notifications {
+		sendMessageToChat(notification.chatID, notification.text)
+	}
+
+	// saving only once the messages are posted, the reminders might have been changed meanwhile,
+	// so applying the changes to the current ones
+	stateMutex.Lock()
+	remaining := []BlockReminder{}
+	for _, reminder := range state.BlockReminders {
+		key := blockReminderKey{ChatID: reminder.ChatID, Height: reminder.Height}
+		if reached[key] {
+			continue
+		}
+
+		if sent, ok := warningsSent[key]; ok {
+			reminder.WarningsSent = sent
+		}
+
+		remaining = append(remaining, reminder)
+	}
+
+	state.BlockReminders = remaining
+	saveState()
+	stateMutex.Unlock()
+
+	log.Info().
+		Int64("height", block.Height).
+		Int("notifications", len(notifications)).
+		Msg("Sent block reminders")
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+func searchProposals(message *tb.Message) {
+	args := strings.SplitAfterN(message.Text, " ", 2)
+	if len(args) < 2 || strings.TrimSpace(args[1]) == "" {
+		log.Info().Msg("searchProposals: args length < 2")
+		sendMessage(message, "Usage: searchproposal &lt;text&gt;")
+		return
+	}
+
+	query := strings.ToLower(strings.TrimSpace(args[1]))
+	log.Debug().Str("query", query).Msg("searchProposals: query")
+
+	// --------------------------------
+	proposals, err := getProposals(govtypes.StatusNil)
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get proposals")
+		sendMessage(message, "Could not get proposals")
+		return
+	}
+
+	sort.Slice(proposals, func(i, j int) bool {
+		return proposals[i].ProposalId > proposals[j].ProposalId
+	})
+
+	var sb strings.Builder
+	found := 0
+
+	for _, proposal := range proposals {
+		proposalInfo, err := getProposalInfoAsStruct(proposal)
+		if err != nil {
+			log.Error().Err(err).Uint64("id", proposal.ProposalId).Msg("Could not parse proposal")
+			continue
+		}
+
+		if !strings.Contains(strings.ToLower(proposalInfo.Title), query) &&
+			!strings.Contains(strings.ToLower(proposalInfo.Description), query) {
+			continue
+		}
+
+		found++
+		sb.WriteString(fmt.Sprintf(
+			"<code>/proposal %d</code> - <code>%s</code> - %s\n",
+			proposal.ProposalId,
+			proposal.Status,
+			escapeHTML(proposalInfo.Title),
+		))
+	}
+
+	// --------------------------------
+
+	if found == 0 {
+		sendMessage(message, fmt.Sprintf("No proposals found for <code>%s</code>", escapeHTML(query)))
+		return
+	}
+
+	text := fmt.Sprintf("<strong>Found %d proposal(s):</strong>\n\n", found) + sb.String()
+	for _, chunk := range splitMessage(text) {
+		sendMessage(message, chunk)
+	}
+
+	log.Info().
+		Str("query", query).
+		Int("found", found).
+		Str("user", message.Sender.Username).
+		Msg("Successfully returned proposals search results")
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// State is everything the bot has to remember between restarts:
+// chat subscriptions and the last seen values the background monitors compare against.
+type State struct {
+	ValidatorSubscriptions map[string][]int64           `json:"validator_subscriptions"`
+	ValidatorSnapshots     map[string]ValidatorSnapshot `json:"validator_snapshots"`
+
+	DecentralizationSnapshot *DecentralizationMetrics `json:"decentralization_snapshot,omitempty"`
+
+	GovernanceSubscriptions []int64 `json:"governance_subscriptions"`
+	// nil until the first governance check, so the existing proposals won't be announced
+	ProposalSnapshots map[uint64]ProposalSnapshot `json:"proposal_snapshots"`
+
+	UpgradeSubscriptions []int64 `json:"upgrade_subscriptions"`
+	// lead times of the countdowns already posted, by upgrade name
+	UpgradeRemindersSent map[string][]time.Duration `json:"upgrade_reminders_sent"`
+
+	BlockReminders []BlockReminder `json:"block_reminders"`
+
+	HaltSubscriptions []int64 `json:"halt_subscriptions"`
+	// nil if the chain is producing blocks
+	ChainHalt *ChainHalt `json:"chain_halt,omitempty"`
+}
+
+var (
+	state      State
+	stateMutex sync.Mutex
+)
+
+func loadState() error {
+	state = State{}
+
+	if StatePath != "" {
+		if bytes, err := ioutil.ReadFile(StatePath); err == nil {
+			if err := json.Unmarshal(bytes, &state); err != nil {
+				return err
+			}
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	if state.ValidatorSubscriptions == nil {
+		state.ValidatorSubscriptions = make(map[string][]int64)
+	}
+
+	if state.ValidatorSnapshots == nil {
+		state.ValidatorSnapshots = make(map[string]ValidatorSnapshot)
+	}
+
+	if state.UpgradeRemindersSent == nil {
+		state.UpgradeRemindersSent = make(map[string][]time.Duration)
+	}
+
+	return nil
+}
+
+// saveState writes the state to disk, should be called with stateMutex held.
+func saveState() {
+	if StatePath == "" {
+		return
+	}
+
+	bytes, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		log.Error().Err(err).Msg("Could not serialize state")
+		return
+	}
+
+	// writing to a temporary file first so a crash won't leave a half-written state
+	tmpPath := StatePath + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, bytes, 0600); err != nil {
+		log.Error().Err(err).Str("path", tmpPath).Msg("Could not write state")
+		return
+	}
+
+	if err := os.Rename(tmpPath, StatePath); err != nil {
+		log.Error().Err(err).Str("path", StatePath).Msg("Could not write state")
+	}
+}
+
+func addChatToList(chats []int64, chatID int64) ([]int64, bool) {
+	for _, chat := range chats {
+		if chat == chatID {
+			return chats, false
+		}
+	}
+
+	return append(chats, chatID), true
+}
+
+func removeChatFromList(chats []int64, chatID int64) ([]int64, bool) {
+	for index, chat := range chats {
+		if chat == chatID {
+			return append(chats[:index], chats[index+1:]...), true
+		}
+	}
+
+	return chats, false
+}
+
+// subscribeChat adds the message's chat to the subscribers list of the state,
+// the list is only accessed with the state locked.
+func subscribeChat(message *tb.Message, chats *[]int64, topic string, unsubscribeCommand string) {
+	stateMutex.Lock()
+	updated, added := addChatToList(*chats, message.Chat.ID)
+	*chats = updated
+	saveState()
+	stateMutex.Unlock()
+
+	if !added {
+		sendMessage(message, fmt.Sprintf("This chat is already subscribed to %s", topic))
+		return
+	}
+
+	sendMessage(message, fmt.Sprintf("Subscribed to %s. Use /%s to unsubscribe.", topic, unsubscribeCommand))
+	log.Info().
+		Int64("chat", message.Chat.ID).
+		Str("user", message.Sender.Username).
+		Msg("Successfully subscribed to " + topic)
+}
+
+func unsubscribeChat(message *tb.Message, chats *[]int64, topic string) {
+	stateMutex.Lock()
+	updated, removed := removeChatFromList(*chats, message.Chat.ID)
+	*chats = updated
+	saveState()
+	stateMutex.Unlock()
+
+	if !removed {
+		sendMessage(message, fmt.Sprintf("This chat is not subscribed to %s", topic))
+		return
+	}
+
+	sendMessage(message, fmt.Sprintf("Unsubscribed from %s", topic))
+	log.Info().
+		Int64("chat", message.Chat.ID).
+		Str("user", message.Sender.Username).
+		Msg("Successfully unsubscribed from " + topic)
+}
+
+// getAlertChats returns the chats to post an alert to: the subscribed ones
+// and the one provided with --telegram-chat, if any.
+func getAlertChats(chats []int64) []int64 {
+	result := make([]int64, len(chats))
+	copy(result, chats)
+
+	if TelegramChat != 0 {
+		result, _ = addChatToList(result, int64(TelegramChat))
+	}
+
+	return result
+}
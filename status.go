@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	tmrpc "github.com/tendermint/tendermint/rpc/client/http"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+func getStatus(message *tb.Message) {
+	// --------------------------------
+	var status *coretypes.ResultStatus
+	var abciInfo *coretypes.ResultABCIInfo
+
+	tendermintStart := time.Now()
+	tendermintErr := tendermintClient.Query(func(ctx context.Context, client *tmrpc.HTTP) error {
+		var err error
+		if status, err = client.Status(ctx); err != nil {
+			return err
+		}
+
+		abciInfo, err = client.ABCIInfo(ctx)
+		return err
+	})
+	tendermintLatency := time.Since(tendermintStart)
+
+	if tendermintErr != nil {
+		log.Error().Err(tendermintErr).Msg("getStatus: Could not get Tendermint status")
+	}
+
+	grpcStart := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), TendermintTimeout)
+	grpcBlock, grpcErr := tmservice.NewServiceClient(grpcConn).GetLatestBlock(
+		ctx,
+		&tmservice.GetLatestBlockRequest{},
+	)
+	cancel()
+	grpcLatency := time.Since(grpcStart)
+
+	if grpcErr != nil {
+		log.Error().Err(grpcErr).Msg("getStatus: Could not get latest block via gRPC")
+	}
+
+	// --------------------------------
+
+	var sb strings.Builder
+
+	if halt := getChainHalt(); halt != nil {
+		sb.WriteString(fmt.Sprintf(
+			"🚨 <strong>The chain is halted!</strong> No new blocks for <code>%s</code>, since block #%d at <code>%s</code>.\n\n",
+			time.Since(halt.LastBlockTime).Round(time.Second).String(),
+			halt.Height,
+			halt.LastBlockTime.Format(time.RFC822),
+		))
+	}
+
+	if tendermintErr != nil {
+		sb.WriteString(fmt.Sprintf("<strong>Tendermint RPC: </strong>❌ <code>%s</code>\n", escapeHTML(tendermintErr.Error())))
+	} else {
+		sb.WriteString(fmt.Sprintf("<strong>Chain ID: </strong><code>%s</code>\n", escapeHTML(status.NodeInfo.Network)))
+		sb.WriteString(fmt.Sprintf("<strong>Latest block: </strong><code>%d</code>\n", status.SyncInfo.LatestBlockHeight))
+		sb.WriteString(fmt.Sprintf(
+			"<strong>Latest block time: </strong><code>%s</code> (<code>%s</code> ago)\n",
+			status.SyncInfo.LatestBlockTime.Format(time.RFC822),
+			time.Since(status.SyncInfo.LatestBlockTime).Round(time.Second).String(),
+		))
+		sb.WriteString(fmt.Sprintf("<strong>Catching up: </strong><code>%t</code>\n", status.SyncInfo.CatchingUp))
+		sb.WriteString(fmt.Sprintf("<strong>Node version: </strong><code>%s</code>\n", escapeHTML(status.NodeInfo.Version)))
+		sb.WriteString(fmt.Sprintf(
+			"<strong>App version: </strong><code>%s</code> (<code>%s</code>)\n",
+			escapeHTML(abciInfo.Response.Version),
+			escapeHTML(abciInfo.Response.Data),
+		))
+		sb.WriteString(fmt.Sprintf("<strong>Tendermint RPC latency: </strong><code>%s</code>\n", tendermintLatency.Round(time.Millisecond)))
+	}
+
+	if grpcErr != nil {
+		sb.WriteString(fmt.Sprintf("<strong>gRPC: </strong>❌ <code>%s</code>\n", escapeHTML(grpcErr.Error())))
+	} else {
+		sb.WriteString(fmt.Sprintf("<strong>gRPC: </strong>✅ latest block <code>%d</code>\n", grpcBlock.Block.Header.Height))
+		sb.WriteString(fmt.Sprintf("<strong>gRPC latency: </strong><code>%s</code>\n", grpcLatency.Round(time.Millisecond)))
+	}
+
+	sb.WriteString("\n<strong>Tendermint RPC nodes:</strong>\n")
+	for _, node := range tendermintClient.GetNodes() {
+		sb.WriteString(fmt.Sprintf("%s <code>%s</code>\n", formatCheckmark(node.Healthy), escapeHTML(node.Address)))
+	}
+
+	sendMessage(message, sb.String())
+	log.Info().
+		Str("user", message.Sender.Username).
+		Msg("Successfully returned status")
+}
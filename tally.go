@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+func getTallyParams() (govtypes.TallyParams, error) {
+	govClient := govtypes.NewQueryClient(grpcConn)
+	paramsResponse, err := govClient.Params(
+		context.Background(),
+		&govtypes.QueryParamsRequest{ParamsType: govtypes.ParamTallying},
+	)
+
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get tally params")
+		return govtypes.TallyParams{}, err
+	}
+
+	return paramsResponse.TallyParams, nil
+}
+
+// serializeProposalTally returns the current tally of a proposal in voting period
+// and what would happen to it if the voting ended right now.
+func serializeProposalTally(proposal govtypes.Proposal) (string, error) {
+	govClient := govtypes.NewQueryClient(grpcConn)
+	tallyResponse, err := govClient.TallyResult(
+		context.Background(),
+		&govtypes.QueryTallyResultRequest{ProposalId: proposal.ProposalId},
+	)
+
+	if err != nil {
+		log.Error().
+			Uint64("id", proposal.ProposalId).
+			Err(err).
+			Msg("Could not get tally result")
+		return "", err
+	}
+
+	tallyParams, err := getTallyParams()
+	if err != nil {
+		return "", err
+	}
+
+	stakingClient := stakingtypes.NewQueryClient(grpcConn)
+	poolResponse, err := stakingClient.Pool(
+		context.Background(),
+		&stakingtypes.QueryPoolRequest{},
+	)
+
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get staking pool")
+		return "", err
+	}
+
+	tally := tallyResponse.Tally
+	yes := tally.Yes.ToDec()
+	no := tally.No.ToDec()
+	abstain := tally.Abstain.ToDec()
+	veto := tally.NoWithVeto.ToDec()
+	totalVoted := yes.Add(no).Add(abstain).Add(veto)
+	bonded := poolResponse.Pool.BondedTokens.ToDec()
+
+	var sb strings.Builder
+	sb.WriteString("<strong>Current tally:</strong>\n")
+	sb.WriteString(fmt.Sprintf("Yes:          <code>%s</code>\n", formatShare(yes, totalVoted)))
+	sb.WriteString(fmt.Sprintf("No:           <code>%s</code>\n", formatShare(no, totalVoted)))
+	sb.WriteString(fmt.Sprintf("Abstain:      <code>%s</code>\n", formatShare(abstain, totalVoted)))
+	sb.WriteString(fmt.Sprintf("No with veto: <code>%s</code>\n", formatShare(veto, totalVoted)))
+
+	quorumReached := !bonded.IsZero() && totalVoted.Quo(bonded).GTE(tallyParams.Quorum)
+	sb.WriteString(fmt.Sprintf(
+		"Turnout:      <code>%s</code> (quorum is <code>%s</code>) %s\n",
+		formatShare(totalVoted, bonded),
+		formatDecAsPercent(tallyParams.Quorum),
+		formatCheckmark(quorumReached),
+	))
+
+	// abstain votes are not counted when checking the threshold
+	nonAbstained := totalVoted.Sub(abstain)
+	thresholdReached := !nonAbstained.IsZero() && yes.Quo(nonAbstained).GT(tallyParams.Threshold)
+	sb.WriteString(fmt.Sprintf(
+		"Pass threshold: <code>%s</code> of non-abstained (needs more than <code>%s</code>) %s\n",
+		formatShare(yes, nonAbstained),
+		formatDecAsPercent(tallyParams.Threshold),
+		formatCheckmark(thresholdReached),
+	))
+
+	vetoed := !totalVoted.IsZero() && veto.Quo(totalVoted).GT(tallyParams.VetoThreshold)
+	sb.WriteString(fmt.Sprintf(
+		"Veto threshold: <code>%s</code> (vetoed if more than <code>%s</code>) %s\n",
+		formatShare(veto, totalVoted),
+		formatDecAsPercent(tallyParams.VetoThreshold),
+		formatCheckmark(!vetoed),
+	))
+
+	// same order of checks as the gov module does when tallying
+	var outcome string
+	switch {
+	case !quorumReached:
+		outcome = "rejected, quorum is not reached"
+	case nonAbstained.IsZero():
+		outcome = "rejected, everyone abstained"
+	case vetoed:
+		outcome = "rejected, vetoed"
+	case thresholdReached:
+		outcome = "passed"
+	default:
+		outcome = "rejected, not enough yes votes"
+	}
+
+	sb.WriteString(fmt.Sprintf("<strong>If voting ended now: </strong><code>%s</code>\n", outcome))
+
+	return sb.String(), nil
+}
+
+func formatShare(value sdk.Dec, total sdk.Dec) string {
+	if total.IsZero() {
+		return "0.00%"
+	}
+
+	return formatDecAsPercent(value.Quo(total))
+}
+
+func formatDecAsPercent(value sdk.Dec) string {
+	parsed, err := decToFloat(value)
+	if err != nil {
+		return value.String()
+	}
+
+	return fmt.Sprintf("%.2f%%", parsed*100)
+}
+
+func formatCheckmark(value bool) string {
+	if value {
+		return "✅"
+	}
+
+	return "❌"
+}
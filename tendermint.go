@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	tmrpc "github.com/tendermint/tendermint/rpc/client/http"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+// TendermintNode is a single Tendermint RPC endpoint with its last known health status.
+type TendermintNode struct {
+	Address string
+	Client  *tmrpc.HTTP
+	Healthy bool
+}
+
+// TendermintClient is a long-lived Tendermint RPC client shared across handlers.
+// It keeps the list of the endpoints and fails over to the next healthy one
+// if the current one does not respond.
+type TendermintClient struct {
+	nodes   []*TendermintNode
+	current int
+	mutex   sync.RWMutex
+}
+
+func NewTendermintClient(addresses []string) (*TendermintClient, error) {
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("no Tendermint RPC endpoints provided")
+	}
+
+	nodes := make([]*TendermintNode, len(addresses))
+	for index, address := range addresses {
+		client, err := tmrpc.New(address, "/websocket")
+		if err != nil {
+			return nil, fmt.Errorf("could not create Tendermint client for %s: %s", address, err)
+		}
+
+		// considering all the nodes healthy until the first health check says otherwise
+		nodes[index] = &TendermintNode{Address: address, Client: client, Healthy: true}
+	}
+
+	return &TendermintClient{nodes: nodes}, nil
+}
+
+// Query runs the request against the current endpoint, each attempt with its own
+// TendermintTimeout, and retries it on the other endpoints if the node could not be reached.
+// Healthy endpoints are tried first. Errors returned by the node itself (like a pruned height
+// or an unknown transaction) are returned as is, as the other nodes will most likely return the same.
+func (c *TendermintClient) Query(request func(ctx context.Context, client *tmrpc.HTTP) error) error {
+	var lastErr error
+
+	for _, index := range c.getQueryOrder() {
+		node := c.nodes[index]
+
+		ctx, cancel := context.WithTimeout(context.Background(), TendermintTimeout)
+		err := request(ctx, node.Client)
+		cancel()
+
+		if err == nil {
+			c.setHealthy(index, true)
+			return nil
+		}
+
+		if !isTransportError(err) {
+			return err
+		}
+
+		log.Warn().
+			Str("node", node.Address).
+			Err(err).
+			Msg("Tendermint RPC request failed, trying the next node")
+
+		c.setHealthy(index, false)
+		lastErr = err
+	}
+
+	return lastErr
+}
+
+// isTransportError returns true if the node could not be reached, did not respond in time
+// or responded with something that is not JSON, like the 502 page of a reverse proxy.
+func isTransportError(err error) bool {
+	var rpcErr *rpctypes.RPCError
+	if errors.As(err, &rpcErr) {
+		return false
+	}
+
+	var urlErr *url.Error
+	var netErr net.Error
+	var syntaxErr *json.SyntaxError
+	var unmarshalTypeErr *json.UnmarshalTypeError
+
+	return errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.As(err, &urlErr) ||
+		errors.As(err, &netErr) ||
+		errors.As(err, &syntaxErr) ||
+		errors.As(err, &unmarshalTypeErr)
+}
+
+// getQueryOrder returns the indexes of the endpoints starting with the current one,
+// the healthy endpoints going before the unhealthy ones.
+func (c *TendermintClient) getQueryOrder() []int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	healthy := []int{}
+	unhealthy := []int{}
+
+	for offset := range c.nodes {
+		index := (c.current + offset) % len(c.nodes)
+		if c.nodes[index].Healthy {
+			healthy = append(healthy, index)
+		} else {
+			unhealthy = append(unhealthy, index)
+		}
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+func (c *TendermintClient) setHealthy(index int, healthy bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.nodes[index].Healthy = healthy
+	if healthy && !c.nodes[c.current].Healthy {
+		log.Info().Str("node", c.nodes[index].Address).Msg("Switching to another Tendermint node")
+		c.current = index
+	}
+}
+
+// GetCurrentNode returns the copy of the endpoint the requests go to first.
+func (c *TendermintClient) GetCurrentNode() TendermintNode {
+	return c.GetNodes()[c.getQueryOrder()[0]]
+}
+
+// GetNodes returns the copies of the endpoints with their current health status.
+func (c *TendermintClient) GetNodes() []TendermintNode {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	nodes := make([]TendermintNode, len(c.nodes))
+	for index, node := range c.nodes {
+		nodes[index] = *node
+	}
+
+	return nodes
+}
+
+// CheckHealth queries every endpoint's status, marking the ones that do not respond
+// or are catching up as unhealthy.
+func (c *TendermintClient) CheckHealth() {
+	for index, node := range c.nodes {
+		ctx, cancel := context.WithTimeout(context.Background(), TendermintTimeout)
+		status, err := node.Client.Status(ctx)
+		cancel()
+
+		healthy := err == nil && !status.SyncInfo.CatchingUp
+		if !healthy {
+			log.Warn().
+				Str("node", node.Address).
+				Err(err).
+				Msg("Tendermint node is unhealthy")
+		}
+
+		c.setHealthy(index, healthy)
+	}
+}
+
+func startTendermintHealthCheck() {
+	for {
+		tendermintClient.CheckHealth()
+		time.Sleep(TendermintHealthCheckInterval)
+	}
+}
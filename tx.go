@@ -0,0 +1,335 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	ibctransfertypes "github.com/cosmos/cosmos-sdk/x/ibc/applications/transfer/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/gogo/protobuf/proto"
+	tmrpc "github.com/tendermint/tendermint/rpc/client/http"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// MaxRawValueLength is how many characters of the base64 value are shown for a message
+// that cannot be decoded.
+const MaxRawValueLength = 256
+
+func getTxInfo(message *tb.Message) {
+	args := strings.SplitAfterN(message.Text, " ", 2)
+	if len(args) < 2 {
+		log.Info().Msg("getTxInfo: args length < 2")
+		sendMessage(message, "Usage: tx &lt;hash&gt;")
+		return
+	}
+
+	hash, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(args[1]), "0x"))
+	if err != nil {
+		log.Info().Err(err).Msg("getTxInfo: Could not parse hash")
+		sendMessage(message, "Transaction hash should be a hex string")
+		return
+	}
+
+	// --------------------------------
+	var result *coretypes.ResultTx
+	err = tendermintClient.Query(func(ctx context.Context, client *tmrpc.HTTP) error {
+		var err error
+		result, err = client.Tx(ctx, hash, false)
+		return err
+	})
+
+	if err != nil {
+		log.Error().Err(err).Str("hash", args[1]).Msg("getTxInfo: Could not get transaction")
+		sendMessage(message, "Could not find transaction")
+		return
+	}
+
+	// not using the TxConfig decoder, as it fails on the whole transaction if any of its messages
+	// has a type the interface registry doesn't know, so the messages are unpacked one by one instead
+	var txRaw txtypes.TxRaw
+	var txBody txtypes.TxBody
+	var authInfo txtypes.AuthInfo
+
+	if err := proto.Unmarshal(result.Tx, &txRaw); err != nil {
+		log.Error().Err(err).Msg("getTxInfo: Could not decode transaction")
+		sendMessage(message, "Could not decode transaction")
+		return
+	}
+
+	if err := proto.Unmarshal(txRaw.BodyBytes, &txBody); err != nil {
+		log.Error().Err(err).Msg("getTxInfo: Could not decode transaction body")
+		sendMessage(message, "Could not decode transaction")
+		return
+	}
+
+	if err := proto.Unmarshal(txRaw.AuthInfoBytes, &authInfo); err != nil {
+		log.Error().Err(err).Msg("getTxInfo: Could not decode transaction auth info")
+		sendMessage(message, "Could not decode transaction")
+		return
+	}
+
+	protoCodec := codec.NewProtoCodec(interfaceRegistry)
+
+	bondDenom, err := getBondDenom()
+	if err != nil {
+		sendMessage(message, "Could not get bond denom")
+		return
+	}
+
+	block, blockErr := getBlock(&result.Height)
+	if blockErr != nil {
+		log.Error().Err(blockErr).Msg("getTxInfo: Could not get transaction block")
+	}
+
+	// --------------------------------
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<strong>Transaction </strong><code>%s</code>\n", result.Hash.String()))
+	sb.WriteString(fmt.Sprintf("<strong>Height: </strong><code>%d</code>\n", result.Height))
+	if blockErr == nil {
+		sb.WriteString(fmt.Sprintf("<strong>Time: </strong><code>%s</code>\n", block.Time.Format(time.RFC822)))
+	}
+
+	if result.TxResult.Code == 0 {
+		sb.WriteString("<strong>Status: </strong>✅ Success\n")
+	} else {
+		sb.WriteString(fmt.Sprintf(
+			"<strong>Status: </strong>❌ Failed, code <code>%d</code> (<code>%s</code>)\n",
+			result.TxResult.Code,
+			escapeHTML(result.TxResult.Codespace),
+		))
+		sb.WriteString(fmt.Sprintf("<strong>Log: </strong><code>%s</code>\n", escapeHTML(result.TxResult.Log)))
+	}
+
+	sb.WriteString(fmt.Sprintf(
+		"<strong>Gas used/wanted: </strong><code>%s</code>/<code>%s</code>\n",
+		Printer.Sprintf("%d", result.TxResult.GasUsed),
+		Printer.Sprintf("%d", result.TxResult.GasWanted),
+	))
+
+	if authInfo.Fee != nil {
+		sb.WriteString(fmt.Sprintf("<strong>Fee: </strong>%s\n", serializeCoins(authInfo.Fee.Amount, bondDenom)))
+	}
+
+	if txBody.Memo != "" {
+		sb.WriteString(fmt.Sprintf("<strong>Memo: </strong><code>%s</code>\n", escapeHTML(txBody.Memo)))
+	}
+
+	if signers := getTxSigners(authInfo); len(signers) > 0 {
+		sb.WriteString("<strong>Signers:</strong>\n")
+		for _, signer := range signers {
+			sb.WriteString(fmt.Sprintf(
+				"- <a href=\"https://mintscan.io/%s/account/%s\">%s</a>\n",
+				MintscanPrefix,
+				signer,
+				signer,
+			))
+		}
+	}
+
+	sb.WriteString("\n<strong>Messages:</strong>\n")
+	for index, msgAny := range txBody.Messages {
+		var msg sdk.Msg
+		if err := interfaceRegistry.UnpackAny(msgAny, &msg); err != nil {
+			log.Debug().Err(err).Str("type", msgAny.TypeUrl).Msg("Could not unpack transaction message")
+			sb.WriteString(fmt.Sprintf(
+				"%d. <code>%s</code>\n<pre>%s</pre>\n",
+				index+1,
+				escapeHTML(msgAny.TypeUrl),
+				truncateRawValue(msgAny.Value),
+			))
+			continue
+		}
+
+		sb.WriteString(fmt.Sprintf("%d. %s\n", index+1, serializeTxMessage(msg, bondDenom, protoCodec)))
+	}
+
+	sb.WriteString(fmt.Sprintf("\n<a href=\"https://mintscan.io/%s/txs/%s\">Mintscan</a>\n", MintscanPrefix, result.Hash.String()))
+
+	for _, chunk := range splitMessage(sb.String()) {
+		sendMessage(message, chunk)
+	}
+
+	log.Info().
+		Str("hash", result.Hash.String()).
+		Str("user", message.Sender.Username).
+		Msg("Successfully returned transaction info")
+}
+
+// truncateRawValue returns the base64 of the message that cannot be decoded, cut to MaxRawValueLength
+// characters, as some of them (like CosmWasm contracts code) take megabytes.
+func truncateRawValue(value []byte) string {
+	encoded := base64.StdEncoding.EncodeToString(value)
+	if len(encoded) <= MaxRawValueLength {
+		return encoded
+	}
+
+	return fmt.Sprintf("%s… (%d bytes)", encoded[:MaxRawValueLength], len(value))
+}
+
+// getTxSigners returns the account addresses of the transaction signers, taken from their pubkeys.
+// msg.GetSigners() cannot be used here, as it panics if the sdk config doesn't know the account prefix.
+func getTxSigners(authInfo txtypes.AuthInfo) []string {
+	signers := []string{}
+	for _, signerInfo := range authInfo.SignerInfos {
+		if signerInfo.PublicKey == nil {
+			continue
+		}
+
+		var pubkey cryptotypes.PubKey
+		if err := interfaceRegistry.UnpackAny(signerInfo.PublicKey, &pubkey); err != nil {
+			log.Error().Err(err).Str("type", signerInfo.PublicKey.TypeUrl).Msg("Could not unpack signer pubkey")
+			continue
+		}
+
+		address, err := bech32.ConvertAndEncode(AccountPrefix, pubkey.Address())
+		if err != nil {
+			log.Error().Err(err).Msg("Could not encode signer address")
+			continue
+		}
+
+		signers = append(signers, address)
+	}
+
+	return signers
+}
+
+// serializeTxMessage returns a human-readable description of the transaction message,
+// falling back to its type URL and JSON for the unknown message types.
+func serializeTxMessage(msg sdk.Msg, bondDenom string, protoCodec *codec.ProtoCodec) string {
+	switch parsedMsg := msg.(type) {
+	case *banktypes.MsgSend:
+		return fmt.Sprintf(
+			"Send %s from <code>%s</code> to <code>%s</code>",
+			serializeCoins(parsedMsg.Amount, bondDenom),
+			escapeHTML(parsedMsg.FromAddress),
+			escapeHTML(parsedMsg.ToAddress),
+		)
+	case *banktypes.MsgMultiSend:
+		return fmt.Sprintf(
+			"Multi-send from %d inputs to %d outputs",
+			len(parsedMsg.Inputs),
+			len(parsedMsg.Outputs),
+		)
+	case *stakingtypes.MsgDelegate:
+		return fmt.Sprintf(
+			"Delegate %s from <code>%s</code> to %s",
+			serializeCoins(sdk.Coins{parsedMsg.Amount}, bondDenom),
+			escapeHTML(parsedMsg.DelegatorAddress),
+			serializeValidatorLink(parsedMsg.ValidatorAddress),
+		)
+	case *stakingtypes.MsgUndelegate:
+		return fmt.Sprintf(
+			"Undelegate %s from %s to <code>%s</code>",
+			serializeCoins(sdk.Coins{parsedMsg.Amount}, bondDenom),
+			serializeValidatorLink(parsedMsg.ValidatorAddress),
+			escapeHTML(parsedMsg.DelegatorAddress),
+		)
+	case *stakingtypes.MsgBeginRedelegate:
+		return fmt.Sprintf(
+			"Redelegate %s of <code>%s</code> from %s to %s",
+			serializeCoins(sdk.Coins{parsedMsg.Amount}, bondDenom),
+			escapeHTML(parsedMsg.DelegatorAddress),
+			serializeValidatorLink(parsedMsg.ValidatorSrcAddress),
+			serializeValidatorLink(parsedMsg.ValidatorDstAddress),
+		)
+	case *stakingtypes.MsgCreateValidator:
+		return fmt.Sprintf(
+			"Create validator <code>%s</code> with self-delegation of %s",
+			escapeHTML(parsedMsg.Description.Moniker),
+			serializeCoins(sdk.Coins{parsedMsg.Value}, bondDenom),
+		)
+	case *stakingtypes.MsgEditValidator:
+		return fmt.Sprintf("Edit validator %s", serializeValidatorLink(parsedMsg.ValidatorAddress))
+	case *distributiontypes.MsgWithdrawDelegatorReward:
+		return fmt.Sprintf(
+			"Withdraw rewards of <code>%s</code> from %s",
+			escapeHTML(parsedMsg.DelegatorAddress),
+			serializeValidatorLink(parsedMsg.ValidatorAddress),
+		)
+	case *distributiontypes.MsgWithdrawValidatorCommission:
+		return fmt.Sprintf("Withdraw commission of %s", serializeValidatorLink(parsedMsg.ValidatorAddress))
+	case *distributiontypes.MsgSetWithdrawAddress:
+		return fmt.Sprintf(
+			"Set withdraw address of <code>%s</code> to <code>%s</code>",
+			escapeHTML(parsedMsg.DelegatorAddress),
+			escapeHTML(parsedMsg.WithdrawAddress),
+		)
+	case *distributiontypes.MsgFundCommunityPool:
+		return fmt.Sprintf(
+			"Fund community pool with %s from <code>%s</code>",
+			serializeCoins(parsedMsg.Amount, bondDenom),
+			escapeHTML(parsedMsg.Depositor),
+		)
+	case *govtypes.MsgVote:
+		return fmt.Sprintf(
+			"Vote <code>%s</code> on proposal #%d from <code>%s</code>",
+			formatVoteOption(parsedMsg.Option.String()),
+			parsedMsg.ProposalId,
+			escapeHTML(parsedMsg.Voter),
+		)
+	case *govtypes.MsgDeposit:
+		return fmt.Sprintf(
+			"Deposit %s on proposal #%d from <code>%s</code>",
+			serializeCoins(parsedMsg.Amount, bondDenom),
+			parsedMsg.ProposalId,
+			escapeHTML(parsedMsg.Depositor),
+		)
+	case *govtypes.MsgSubmitProposal:
+		title := "unknown proposal"
+		if content := parsedMsg.GetContent(); content != nil {
+			title = content.GetTitle()
+		}
+
+		return fmt.Sprintf(
+			"Submit proposal <code>%s</code> with deposit %s from <code>%s</code>",
+			escapeHTML(title),
+			serializeCoins(parsedMsg.InitialDeposit, bondDenom),
+			escapeHTML(parsedMsg.Proposer),
+		)
+	case *ibctransfertypes.MsgTransfer:
+		return fmt.Sprintf(
+			"IBC transfer %s from <code>%s</code> to <code>%s</code> via <code>%s/%s</code>",
+			serializeCoins(sdk.Coins{parsedMsg.Token}, bondDenom),
+			escapeHTML(parsedMsg.Sender),
+			escapeHTML(parsedMsg.Receiver),
+			escapeHTML(parsedMsg.SourcePort),
+			escapeHTML(parsedMsg.SourceChannel),
+		)
+	case *slashingtypes.MsgUnjail:
+		return fmt.Sprintf("Unjail %s", serializeValidatorLink(parsedMsg.ValidatorAddr))
+	}
+
+	typeURL := "/" + proto.MessageName(msg)
+	json, err := protoCodec.MarshalJSON(msg)
+	if err != nil {
+		log.Error().Err(err).Str("type", typeURL).Msg("Could not serialize message to JSON")
+		return fmt.Sprintf("<code>%s</code>", escapeHTML(typeURL))
+	}
+
+	return fmt.Sprintf("<code>%s</code>\n<pre>%s</pre>", escapeHTML(typeURL), escapeHTML(string(json)))
+}
+
+func serializeValidatorLink(address string) string {
+	return fmt.Sprintf(
+		"<a href=\"https://mintscan.io/%s/validators/%s\">%s</a>",
+		MintscanPrefix,
+		escapeHTML(address),
+		escapeHTML(address),
+	)
+}
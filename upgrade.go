@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+func getUpgradeInfo(message *tb.Message) {
+	// --------------------------------
+	plan, err := getCurrentPlan()
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get current upgrade plan")
+		sendMessage(message, "Could not get current upgrade plan")
+		return
+	}
+
+	// --------------------------------
+
+	var sb strings.Builder
+
+	if plan == nil {
+		sb.WriteString("There is no upgrade scheduled at the moment.\n")
+	} else if upgradeTime, err := getUpgradeTime(*plan); err != nil {
+		log.Error().Err(err).Msg("Could not get upgrade time")
+		sendMessage(message, "Could not get upgrade info")
+		return
+	} else {
+		sb.WriteString(serializeUpgradePlan(*plan, upgradeTime))
+	}
+
+	if name, height, err := getPreviousUpgrade(); err != nil {
+		log.Error().Err(err).Msg("Could not get previous upgrade")
+	} else if name != "" {
+		sb.WriteString(fmt.Sprintf(
+			"\n<strong>Previous upgrade: </strong><code>%s</code>, applied at block <code>%d</code>\n",
+			escapeHTML(name),
+			height,
+		))
+	}
+
+	sendMessage(message, sb.String())
+	log.Info().
+		Str("user", message.Sender.Username).
+		Msg("Successfully returned upgrade info")
+}
+
+func serializeUpgradePlan(plan upgradetypes.Plan, upgradeTime time.Time) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<strong>Upgrade: </strong><code>%s</code>\n", escapeHTML(plan.Name)))
+	if plan.Height != 0 {
+		sb.WriteString(fmt.Sprintf("<strong>Height: </strong><code>%d</code>\n", plan.Height))
+		sb.WriteString(fmt.Sprintf("<strong>Estimated time: </strong><code>%s</code>\n", upgradeTime.Format(time.RFC822)))
+	} else {
+		sb.WriteString(fmt.Sprintf("<strong>Time: </strong><code>%s</code>\n", upgradeTime.Format(time.RFC822)))
+	}
+	sb.WriteString(fmt.Sprintf("<code>%s</code> left.\n", time.Until(upgradeTime).Round(time.Minute).String()))
+	if plan.Info != "" {
+		sb.WriteString(fmt.Sprintf("<strong>Info: </strong><code>%s</code>\n", escapeHTML(plan.Info)))
+	}
+
+	return sb.String()
+}
+
+// getUpgradeTime returns the time of the upgrade, estimating it for the height-based upgrades.
+func getUpgradeTime(plan upgradetypes.Plan) (time.Time, error) {
+	if plan.Height == 0 {
+		return plan.Time, nil
+	}
+
+	latestBlock, err := getBlock(nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if plan.Height <= latestBlock.Height {
+		return latestBlock.Time, nil
+	}
+
+	return getEstimatedBlockTime(latestBlock, plan.Height)
+}
+
+// getCurrentPlan returns the currently scheduled upgrade plan, or nil if there's none.
+func getCurrentPlan() (*upgradetypes.Plan, error) {
+	upgradeClient := upgradetypes.NewQueryClient(grpcConn)
+	planResponse, err := upgradeClient.CurrentPlan(
+		context.Background(),
+		&upgradetypes.QueryCurrentPlanRequest{},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return planResponse.Plan, nil
+}
+
+// getPreviousUpgrade returns the name and the height of the latest applied upgrade.
+// Upgrade module can only be queried by the upgrade name, so the names are taken
+// from the passed upgrade proposals.
+func getPreviousUpgrade() (string, int64, error) {
+	proposals, err := getProposals(govtypes.StatusPassed)
+	if err != nil {
+		return "", 0, err
+	}
+
+	sort.Slice(proposals, func(i, j int) bool {
+		return proposals[i].ProposalId > proposals[j].ProposalId
+	})
+
+	upgradeClient := upgradetypes.NewQueryClient(grpcConn)
+
+	for _, proposal := range proposals {
+		var content govtypes.Content
+		if err := interfaceRegistry.UnpackAny(proposal.Content, &content); err != nil {
+			continue
+		}
+
+		upgradeProposal, ok := content.(*upgradetypes.SoftwareUpgradeProposal)
+		if !ok {
+			continue
+		}
+
+		appliedPlanResponse, err := upgradeClient.AppliedPlan(
+			context.Background(),
+			&upgradetypes.QueryAppliedPlanRequest{Name: upgradeProposal.Plan.Name},
+		)
+
+		if err != nil {
+			log.Error().
+				Str("name", upgradeProposal.Plan.Name).
+				Err(err).
+				Msg("Could not get applied plan")
+			continue
+		}
+
+		// upgrades that were cancelled or not yet applied have zero height
+		if appliedPlanResponse.Height != 0 {
+			return upgradeProposal.Plan.Name, appliedPlanResponse.Height, nil
+		}
+	}
+
+	return "", 0, nil
+}
+
+func subscribeToUpgrades(message *tb.Message) {
+	subscribeChat(message, &state.UpgradeSubscriptions, "upgrade countdowns", "unsubscribe_upgrade")
+}
+
+func unsubscribeFromUpgrades(message *tb.Message) {
+	unsubscribeChat(message, &state.UpgradeSubscriptions, "upgrade countdowns")
+}
+
+func startUpgradeMonitor() {
+	for {
+		checkUpgradePlan()
+		time.Sleep(UpgradeMonitorInterval)
+	}
+}
+
+func checkUpgradePlan() {
+	plan, err := getCurrentPlan()
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get current upgrade plan for monitoring")
+		return
+	}
+
+	if plan == nil {
+		return
+	}
+
+	upgradeTime, err := getUpgradeTime(*plan)
+	if err != nil {
+		log.Error().Err(err).Msg("Could not get upgrade time for monitoring")
+		return
+	}
+
+	timeLeft := time.Until(upgradeTime)
+
+	stateMutex.Lock()
+	remindersSent, due := pickDueReminder(UpgradeReminders, state.UpgradeRemindersSent[plan.Name], timeLeft)
+	chats := getAlertChats(state.UpgradeSubscriptions)
+	stateMutex.Unlock()
+
+	if !due {
+		return
+	}
+
+	serializedPlan := serializeUpgradePlan(*plan, upgradeTime)
+	for _, chat := range chats {
+		sendMessageToChat(chat, "⏰ Upgrade is coming soon!\n\n"+serializedPlan)
+	}
+
+	// saving the reminders only once they are posted, so a crash in between won't lose the reminder
+	stateMutex.Lock()
+	state.UpgradeRemindersSent[plan.Name] = remindersSent
+	saveState()
+	stateMutex.Unlock()
+
+	log.Info().
+		Str("name", plan.Name).
+		Str("left", timeLeft.String()).
+		Int("chats", len(chats)).
+		Msg("Sent upgrade countdown")
+}
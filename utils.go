@@ -1,58 +1,122 @@
 package main
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	ibcclienttypes "github.com/cosmos/cosmos-sdk/x/ibc/core/02-client/types"
 	paramstypes "github.com/cosmos/cosmos-sdk/x/params/types/proposal"
 	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
 	"github.com/gogo/protobuf/proto"
 )
 
 type ProposalInfo struct {
+	Type        string
 	Title       string
 	Description string
+	// nil if the proposal type is not known to the interface registry
+	Content govtypes.Content
 }
 
 func getProposalInfoAsStruct(proposal govtypes.Proposal) (ProposalInfo, error) {
-	switch proposal.Content.TypeUrl {
-	case "/cosmos.upgrade.v1beta1.SoftwareUpgradeProposal":
-		var parsedMessage upgradetypes.SoftwareUpgradeProposal
-		if err := proto.Unmarshal(proposal.Content.Value, &parsedMessage); err != nil {
-			log.Error().Err(err).Msg("Could not parse SoftwareUpgradeProposal")
-			return ProposalInfo{}, err
-		} else {
-			return ProposalInfo{Title: parsedMessage.Title, Description: parsedMessage.Description}, nil
-		}
-	case "/cosmos.gov.v1beta1.TextProposal":
+	if proposal.Content == nil {
+		return ProposalInfo{}, fmt.Errorf("proposal has no content")
+	}
+
+	var content govtypes.Content
+	if err := interfaceRegistry.UnpackAny(proposal.Content, &content); err != nil {
+		log.Debug().
+			Err(err).
+			Str("type", proposal.Content.TypeUrl).
+			Msg("Could not unpack proposal content, trying to get title and description only")
+
+		// almost every proposal type has title and description as the first two fields,
+		// so it can be decoded as a text proposal, ignoring the rest of the fields
 		var parsedMessage govtypes.TextProposal
 		if err := proto.Unmarshal(proposal.Content.Value, &parsedMessage); err != nil {
-			log.Error().Err(err).Msg("Could not parse TextProposal")
+			log.Error().Err(err).Str("type", proposal.Content.TypeUrl).Msg("Could not parse proposal")
 			return ProposalInfo{}, err
-		} else {
-			return ProposalInfo{Title: parsedMessage.Title, Description: parsedMessage.Description}, nil
+		}
+
+		return ProposalInfo{
+			Type:        proposal.Content.TypeUrl,
+			Title:       parsedMessage.Title,
+			Description: parsedMessage.Description,
+		}, nil
+	}
+
+	return ProposalInfo{
+		Type:        content.ProposalType(),
+		Title:       content.GetTitle(),
+		Description: content.GetDescription(),
+		Content:     content,
+	}, nil
+}
+
+// getProposalDetails returns the type-specific proposal info, serialized as HTML.
+// The status is needed to know whether the proposal is still pending.
+func getProposalDetails(content govtypes.Content, status govtypes.ProposalStatus) string {
+	var sb strings.Builder
 
+	switch parsedMessage := content.(type) {
+	case *upgradetypes.SoftwareUpgradeProposal:
+		sb.WriteString(fmt.Sprintf("Upgrade name:   <code>%s</code>\n", escapeHTML(parsedMessage.Plan.Name)))
+		if parsedMessage.Plan.Height != 0 {
+			sb.WriteString(fmt.Sprintf("Upgrade height: <code>%d</code>\n", parsedMessage.Plan.Height))
 		}
-	case "/cosmos.params.v1beta1.ParameterChangeProposal":
-		var parsedMessage paramstypes.ParameterChangeProposal
-		if err := proto.Unmarshal(proposal.Content.Value, &parsedMessage); err != nil {
-			log.Error().Err(err).Msg("Could not parse ParameterChangeProposal")
-			return ProposalInfo{}, err
-		} else {
-			return ProposalInfo{Title: parsedMessage.Title, Description: parsedMessage.Description}, nil
+		if !parsedMessage.Plan.Time.IsZero() && parsedMessage.Plan.Time.Unix() > 0 {
+			sb.WriteString(fmt.Sprintf("Upgrade time:   <code>%s</code>\n", parsedMessage.Plan.Time.String()))
 		}
-	case "/cosmos.distribution.v1beta1.CommunityPoolSpendProposal":
-		var parsedMessage distributiontypes.CommunityPoolSpendProposal
-		if err := proto.Unmarshal(proposal.Content.Value, &parsedMessage); err != nil {
-			log.Error().Err(err).Msg("Could not parse CommunityPoolSpendProposal")
-			return ProposalInfo{}, err
-		} else {
-			return ProposalInfo{Title: parsedMessage.Title, Description: parsedMessage.Description}, nil
+		if parsedMessage.Plan.Info != "" {
+			sb.WriteString(fmt.Sprintf("Upgrade info:   <code>%s</code>\n", escapeHTML(parsedMessage.Plan.Info)))
+		}
+	case *upgradetypes.CancelSoftwareUpgradeProposal:
+		sb.WriteString("Cancels the currently scheduled upgrade\n")
+	case *paramstypes.ParameterChangeProposal:
+		sb.WriteString("Parameters to change:\n")
+		for _, change := range parsedMessage.Changes {
+			sb.WriteString(fmt.Sprintf("- <code>%s/%s</code>\n", escapeHTML(change.Subspace), escapeHTML(change.Key)))
+
+			// once the proposal passes, the current value is the proposed one, so only comparing the pending ones
+			if status == govtypes.StatusDepositPeriod || status == govtypes.StatusVotingPeriod {
+				if currentValue, err := getParamValue(change.Subspace, change.Key); err != nil {
+					sb.WriteString("  Current:  <i>could not get the current value</i>\n")
+				} else {
+					sb.WriteString(fmt.Sprintf("  Current:  <code>%s</code>\n", escapeHTML(currentValue)))
+				}
+			}
 
+			sb.WriteString(fmt.Sprintf("  Proposed: <code>%s</code>\n", escapeHTML(change.Value)))
+		}
+	case *distributiontypes.CommunityPoolSpendProposal:
+		amount := parsedMessage.Amount.String()
+		if bondDenom, err := getBondDenom(); err == nil {
+			amount = serializeCoins(parsedMessage.Amount, bondDenom)
 		}
+
+		sb.WriteString(fmt.Sprintf(
+			"Recipient: <a href=\"https://mintscan.io/%s/account/%s\">%s</a>\n",
+			MintscanPrefix,
+			escapeHTML(parsedMessage.Recipient),
+			escapeHTML(parsedMessage.Recipient),
+		))
+		sb.WriteString(fmt.Sprintf("Amount:    %s\n", amount))
+	case *ibcclienttypes.ClientUpdateProposal:
+		sb.WriteString(fmt.Sprintf("Client to update: <code>%s</code>\n", escapeHTML(parsedMessage.ClientId)))
 	}
 
-	return ProposalInfo{
-		Title:       "Unsupported proposal type",
-		Description: "Unsupported proposal type",
-	}, nil
+	return sb.String()
+}
+
+// because cosmos's dec doesn't have .toFloat64() method or whatever and returns everything as int
+func decToFloat(value sdk.Dec) (float64, error) {
+	return strconv.ParseFloat(value.String(), 64)
+}
+
+func intToFloat(value sdk.Int) (float64, error) {
+	return strconv.ParseFloat(value.String(), 64)
 }
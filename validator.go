@@ -4,10 +4,11 @@ import (
 	"context"
 	"fmt"
 	"sort"
-	"strconv"
 	"strings"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	querytypes "github.com/cosmos/cosmos-sdk/types/query"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 
 	tb "gopkg.in/tucnak/telebot.v2"
@@ -42,17 +43,16 @@ func getValidatorInfo(message *tb.Message) {
 	// --------------------------------
 
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("<code>%s</code>\n", validator.Description.Moniker))
+	sb.WriteString(fmt.Sprintf("<code>%s</code>\n", escapeHTML(validator.Description.Moniker)))
 	sb.WriteString(fmt.Sprintf("<a href=\"https://mintscan.io/%s/validators/%s\">Mintscan</a>\n\n", MintscanPrefix, validator.OperatorAddress))
 
-	sb.WriteString(fmt.Sprintf("<strong>Moniker: </strong><code>%s</code>\n", validator.Description.Moniker))
+	sb.WriteString(fmt.Sprintf("<strong>Moniker: </strong><code>%s</code>\n", escapeHTML(validator.Description.Moniker)))
 	sb.WriteString(fmt.Sprintf("<strong>Operator address: </strong><code>%s</code>\n", validator.OperatorAddress))
-	sb.WriteString(fmt.Sprintf("<strong>Description: </strong><code>%s</code>\n", validator.Description.Details))
-	sb.WriteString(fmt.Sprintf("<strong>Website: </strong><code>%s</code>\n", validator.Description.Website))
-	sb.WriteString(fmt.Sprintf("<strong>Security contact: </strong><code>%s</code>\n", validator.Description.SecurityContact))
+	sb.WriteString(fmt.Sprintf("<strong>Description: </strong><code>%s</code>\n", escapeHTML(validator.Description.Details)))
+	sb.WriteString(fmt.Sprintf("<strong>Website: </strong><code>%s</code>\n", escapeHTML(validator.Description.Website)))
+	sb.WriteString(fmt.Sprintf("<strong>Security contact: </strong><code>%s</code>\n", escapeHTML(validator.Description.SecurityContact)))
 
-	// because cosmos's dec doesn't have .toFloat64() method or whatever and returns everything as int
-	if value, err := strconv.ParseFloat(validator.Commission.CommissionRates.Rate.String(), 64); err != nil {
+	if value, err := decToFloat(validator.Commission.CommissionRates.Rate); err != nil {
 		log.Error().
 			Str("address", address).
 			Err(err).
@@ -63,7 +63,7 @@ func getValidatorInfo(message *tb.Message) {
 		sb.WriteString(fmt.Sprintf("<strong>Commission rate: </strong><code>%.1f%%</code>\n", value*100))
 	}
 
-	if value, err := strconv.ParseFloat(validator.DelegatorShares.String(), 64); err != nil {
+	if value, err := decToFloat(validator.DelegatorShares); err != nil {
 		log.Error().
 			Str("address", address).
 			Err(err).
@@ -84,6 +84,49 @@ func getValidatorInfo(message *tb.Message) {
 		sb.WriteString(fmt.Sprintf("<strong>Rank: </strong>%d\n", rank))
 	}
 
+	if rewards, err := getValidatorRewards(validator); err != nil {
+		log.Error().
+			Str("address", address).
+			Err(err).
+			Msg("Could not get validator rewards")
+	} else if bondDenom, err := getBondDenom(); err != nil {
+		log.Error().
+			Str("address", address).
+			Err(err).
+			Msg("Could not get bond denom")
+	} else {
+		sb.WriteString(fmt.Sprintf(
+			"<strong>Outstanding rewards: </strong>%s\n",
+			serializeDecCoins(rewards.OutstandingRewards, bondDenom),
+		))
+		sb.WriteString(fmt.Sprintf(
+			"<strong>Unclaimed commission: </strong>%s\n",
+			serializeDecCoins(rewards.Commission, bondDenom),
+		))
+	}
+
+	if dailyCommission, err := getValidatorDailyCommission(validator); err != nil {
+		log.Error().
+			Str("address", address).
+			Err(err).
+			Msg("Could not estimate daily commission")
+	} else {
+		sb.WriteString(Printer.Sprintf(
+			"<strong>Estimated daily commission: </strong><code>%.2f %s</code>\n",
+			dailyCommission/DenomCoefficient,
+			Denom,
+		))
+	}
+
+	if participation, err := getValidatorGovParticipation(validator); err != nil {
+		log.Error().
+			Str("address", address).
+			Err(err).
+			Msg("Could not get governance participation")
+	} else {
+		sb.WriteString("\n" + participation.Serialize())
+	}
+
 	sendMessage(message, sb.String())
 	log.Info().
 		Str("query", address).
@@ -145,6 +188,26 @@ func getValidator(address string) (stakingtypes.Validator, error) {
 }
 
 func getValidatorRank(validator stakingtypes.Validator) (int, error) {
+	validators, err := getSortedValidators()
+	if err != nil {
+		log.Error().
+			Str("address", validator.OperatorAddress).
+			Err(err).
+			Msg("Could not get validators")
+		return 0, err
+	}
+
+	for index, iteratedValidator := range validators {
+		if validator.OperatorAddress == iteratedValidator.OperatorAddress {
+			return index + 1, nil
+		}
+	}
+
+	return 0, fmt.Errorf("could not find validator rank")
+}
+
+// getSortedValidators returns all validators, sorted by their delegator shares descending.
+func getSortedValidators() ([]stakingtypes.Validator, error) {
 	stakingClient := stakingtypes.NewQueryClient(grpcConn)
 	validatorsResponse, err := stakingClient.Validators(
 		context.Background(),
@@ -154,11 +217,7 @@ func getValidatorRank(validator stakingtypes.Validator) (int, error) {
 	)
 
 	if err != nil {
-		log.Error().
-			Str("address", validator.OperatorAddress).
-			Err(err).
-			Msg("Could not get validators")
-		return 0, err
+		return nil, err
 	}
 
 	validators := validatorsResponse.Validators
@@ -167,11 +226,41 @@ func getValidatorRank(validator stakingtypes.Validator) (int, error) {
 		return validators[i].DelegatorShares.RoundInt64() > validators[j].DelegatorShares.RoundInt64()
 	})
 
-	for index, iteratedValidator := range validators {
-		if validator.OperatorAddress == iteratedValidator.OperatorAddress {
-			return index + 1, nil
-		}
+	return validators, nil
+}
+
+func getValidatorConsAddress(validator stakingtypes.Validator) (sdk.ConsAddress, error) {
+	// consensus pubkey is packed into Any, so it has to be unpacked before use
+	if err := validator.UnpackInterfaces(interfaceRegistry); err != nil {
+		log.Error().
+			Str("address", validator.OperatorAddress).
+			Err(err).
+			Msg("Could not unpack validator pubkey")
+		return nil, err
 	}
 
-	return 0, fmt.Errorf("could not find validator rank")
+	return validator.GetConsAddr()
+}
+
+func getValidatorSigningInfo(validator stakingtypes.Validator) (slashingtypes.ValidatorSigningInfo, error) {
+	consAddress, err := getValidatorConsAddress(validator)
+	if err != nil {
+		return slashingtypes.ValidatorSigningInfo{}, err
+	}
+
+	slashingClient := slashingtypes.NewQueryClient(grpcConn)
+	signingInfoResponse, err := slashingClient.SigningInfo(
+		context.Background(),
+		&slashingtypes.QuerySigningInfoRequest{ConsAddress: consAddress.String()},
+	)
+
+	if err != nil {
+		log.Error().
+			Str("address", validator.OperatorAddress).
+			Err(err).
+			Msg("Could not get signing info")
+		return slashingtypes.ValidatorSigningInfo{}, err
+	}
+
+	return signingInfoResponse.ValSigningInfo, nil
 }
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	tmrpc "github.com/tendermint/tendermint/rpc/client/http"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type GovParticipation struct {
+	FinishedVoted int
+	FinishedTotal int
+	// true if the votes on the finished proposals could not be fetched,
+	// for example, because the node doesn't index transactions
+	FinishedUnavailable bool
+	ActiveVotes         []ProposalVote
+}
+
+type ProposalVote struct {
+	ProposalID uint64
+	Voted      bool
+	Option     string
+	// true if the vote could not be fetched, so it's unknown whether the validator voted
+	Unknown bool
+}
+
+func (p GovParticipation) Serialize() string {
+	var sb strings.Builder
+	if p.FinishedUnavailable {
+		sb.WriteString("<strong>Governance participation: </strong>unavailable, could not get votes on finished proposals\n")
+	} else {
+		sb.WriteString(fmt.Sprintf(
+			"<strong>Governance participation: </strong>voted on <code>%d</code> of last <code>%d</code> finished proposals\n",
+			p.FinishedVoted,
+			p.FinishedTotal,
+		))
+	}
+
+	for _, vote := range p.ActiveVotes {
+		if vote.Unknown {
+			sb.WriteString(fmt.Sprintf("<strong>Proposal #%d: </strong>unknown, could not get the vote\n", vote.ProposalID))
+		} else if vote.Voted {
+			sb.WriteString(fmt.Sprintf("<strong>Proposal #%d: </strong><code>%s</code>\n", vote.ProposalID, escapeHTML(vote.Option)))
+		} else {
+			sb.WriteString(fmt.Sprintf("<strong>Proposal #%d: </strong>not voted yet\n", vote.ProposalID))
+		}
+	}
+
+	return sb.String()
+}
+
+// getValidatorAccountAddress returns the address of the account the validator operator votes from.
+func getValidatorAccountAddress(validator stakingtypes.Validator) (string, error) {
+	_, bytes, err := bech32.DecodeAndConvert(validator.OperatorAddress)
+	if err != nil {
+		return "", err
+	}
+
+	// sdk config is not aware of the account prefix, so encoding it manually
+	return bech32.ConvertAndEncode(AccountPrefix, bytes)
+}
+
+func getValidatorGovParticipation(validator stakingtypes.Validator) (GovParticipation, error) {
+	proposals, err := getProposals(govtypes.StatusNil)
+	if err != nil {
+		return GovParticipation{}, err
+	}
+
+	return getGovParticipation(validator, proposals)
+}
+
+// getGovParticipation is the same as getValidatorGovParticipation, but with the proposals
+// already fetched, so they can be reused for several validators. The proposals are not modified.
+func getGovParticipation(validator stakingtypes.Validator, allProposals []govtypes.Proposal) (GovParticipation, error) {
+	voter, err := getValidatorAccountAddress(validator)
+	if err != nil {
+		log.Error().
+			Str("address", validator.OperatorAddress).
+			Err(err).
+			Msg("Could not get validator account address")
+		return GovParticipation{}, err
+	}
+
+	proposals := make([]govtypes.Proposal, len(allProposals))
+	copy(proposals, allProposals)
+	sort.Slice(proposals, func(i, j int) bool {
+		return proposals[i].ProposalId > proposals[j].ProposalId
+	})
+
+	participation := GovParticipation{}
+
+	for _, proposal := range proposals {
+		switch proposal.Status {
+		case govtypes.StatusVotingPeriod:
+			vote := ProposalVote{ProposalID: proposal.ProposalId}
+			if option, found, err := getVote(proposal.ProposalId, voter, true); err != nil {
+				vote.Unknown = true
+			} else if found {
+				vote.Voted = true
+				vote.Option = option
+			}
+
+			participation.ActiveVotes = append(participation.ActiveVotes, vote)
+		case govtypes.StatusPassed, govtypes.StatusRejected, govtypes.StatusFailed:
+			if participation.FinishedUnavailable || participation.FinishedTotal >= GovParticipationProposals {
+				continue
+			}
+
+			_, found, err := getVote(proposal.ProposalId, voter, false)
+			if err != nil {
+				// not counting the proposal as missed, as it's unknown whether the validator voted
+				participation.FinishedUnavailable = true
+				continue
+			}
+
+			participation.FinishedTotal++
+			if found {
+				participation.FinishedVoted++
+			}
+		}
+	}
+
+	// showing the active proposals from the oldest one, as they are ending first
+	sort.Slice(participation.ActiveVotes, func(i, j int) bool {
+		return participation.ActiveVotes[i].ProposalID < participation.ActiveVotes[j].ProposalID
+	})
+
+	return participation, nil
+}
+
+// getVote returns the vote option of the voter on a proposal, if any.
+// The error is returned only if it could not be determined whether the voter voted.
+func getVote(proposalID uint64, voter string, active bool) (string, bool, error) {
+	govClient := govtypes.NewQueryClient(grpcConn)
+	voteResponse, err := govClient.Vote(
+		context.Background(),
+		&govtypes.QueryVoteRequest{ProposalId: proposalID, Voter: voter},
+	)
+
+	if err == nil {
+		return formatVoteOption(voteResponse.Vote.Option.String()), true, nil
+	}
+
+	if !isVoteNotFound(err) {
+		log.Error().
+			Uint64("id", proposalID).
+			Str("voter", voter).
+			Err(err).
+			Msg("Could not get vote")
+		return "", false, err
+	}
+
+	// votes are removed from the store once the proposal is tallied,
+	// so the only way to get them is by searching through the vote transactions
+	if active {
+		return "", false, nil
+	}
+
+	return getVoteFromTxs(proposalID, voter)
+}
+
+// isVoteNotFound checks whether the error means the voter has no vote on the proposal.
+// Cosmos SDK v0.42 returns InvalidArgument for it, the newer versions return NotFound.
+func isVoteNotFound(err error) bool {
+	grpcStatus, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+
+	return grpcStatus.Code() == codes.NotFound ||
+		(grpcStatus.Code() == codes.InvalidArgument && strings.Contains(grpcStatus.Message(), "not found"))
+}
+
+func getVoteFromTxs(proposalID uint64, voter string) (string, bool, error) {
+	page := 1
+	perPage := 1
+	query := fmt.Sprintf(
+		"message.sender='%s' AND %s.%s='%d'",
+		voter,
+		govtypes.EventTypeProposalVote,
+		govtypes.AttributeKeyProposalID,
+		proposalID,
+	)
+
+	// if there are several votes, the latest one counts
+	var result *coretypes.ResultTxSearch
+	err := tendermintClient.Query(func(ctx context.Context, client *tmrpc.HTTP) error {
+		var err error
+		result, err = client.TxSearch(ctx, query, false, &page, &perPage, "desc")
+		return err
+	})
+
+	if err != nil {
+		log.Error().
+			Uint64("id", proposalID).
+			Str("voter", voter).
+			Err(err).
+			Msg("Could not search for vote transactions")
+		return "", false, err
+	}
+
+	if len(result.Txs) == 0 {
+		return "", false, nil
+	}
+
+	for _, event := range result.Txs[0].TxResult.Events {
+		if event.Type != govtypes.EventTypeProposalVote {
+			continue
+		}
+
+		for _, attribute := range event.Attributes {
+			if string(attribute.Key) == govtypes.AttributeKeyOption {
+				return formatVoteOption(string(attribute.Value)), true, nil
+			}
+		}
+	}
+
+	return "", true, nil
+}
+
+func formatVoteOption(option string) string {
+	if parsed, err := govtypes.VoteOptionFromString(option); err == nil {
+		switch parsed {
+		case govtypes.OptionYes:
+			return "Yes"
+		case govtypes.OptionNo:
+			return "No"
+		case govtypes.OptionAbstain:
+			return "Abstain"
+		case govtypes.OptionNoWithVeto:
+			return "No with veto"
+		}
+	}
+
+	return option
+}
@@ -72,8 +72,8 @@ func getWalletInfo(message *tb.Message) {
 	// --------------------------------
 
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("<code>%s</code>\n", address))
-	sb.WriteString(fmt.Sprintf("<a href=\"https://mintscan.io/%s/account/%s\">Mintscan</a>\n\n", MintscanPrefix, address))
+	sb.WriteString(fmt.Sprintf("<code>%s</code>\n", escapeHTML(address)))
+	sb.WriteString(fmt.Sprintf("<a href=\"https://mintscan.io/%s/account/%s\">Mintscan</a>\n\n", MintscanPrefix, escapeHTML(address)))
 
 	sb.WriteString("<strong>Balance:        </strong>")
 
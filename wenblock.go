@@ -3,27 +3,47 @@ package main
 import (
 	"context"
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	tmrpc "github.com/tendermint/tendermint/rpc/client/http"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
 	ctypes "github.com/tendermint/tendermint/types"
 
 	tb "gopkg.in/tucnak/telebot.v2"
 )
 
-var BlocksDiffInThePast int64 = 100
+// BlockTimeSample is the average block time over the given amount of the latest blocks.
+type BlockTimeSample struct {
+	Blocks       int64
+	AvgBlockTime float64
+}
+
+// BlockTimeEstimate is the estimated generation time of a future block. Best is calculated
+// with the median of the sampled average block times, Earliest and Latest with the fastest
+// and the slowest ones.
+type BlockTimeEstimate struct {
+	Best     time.Time
+	Earliest time.Time
+	Latest   time.Time
+	Samples  []BlockTimeSample
+}
 
 func getBlockApproximateDate(message *tb.Message) {
 	args := strings.SplitAfterN(message.Text, " ", 2)
 	if len(args) < 2 {
 		log.Info().Msg("getBlockApproximateDate: args length < 2")
-		sendMessage(message, "Usage: wenblock &lt;proposal ID&gt;")
+		sendMessage(message, "Usage: wenblock &lt;block height or +blocks&gt;")
 		return
 	}
 
-	blockHeightProvided, err := strconv.ParseInt(args[1], 10, 64)
+	heightArg := strings.TrimSpace(args[1])
+	isRelative := strings.HasPrefix(heightArg, "+")
+
+	blockHeightProvided, err := strconv.ParseInt(strings.TrimPrefix(heightArg, "+"), 10, 64)
 	if err != nil {
 		log.Error().Err(err).Msg("getBlockApproximateDate: Could not parse block")
 		sendMessage(message, "Block should be a number!")
@@ -37,6 +57,10 @@ func getBlockApproximateDate(message *tb.Message) {
 		return
 	}
 
+	if isRelative {
+		blockHeightProvided += latestBlock.Height
+	}
+
 	if blockHeightProvided <= latestBlock.Height {
 		log.Debug().Int64("height", latestBlock.Height).Msg("Block is in the past.")
 		if block, err := getBlock(&blockHeightProvided); err != nil {
@@ -55,62 +79,177 @@ func getBlockApproximateDate(message *tb.Message) {
 		return
 	}
 
-	latestHeight := latestBlock.Height
-	beforeLatestBlockHeight := latestBlock.Height - BlocksDiffInThePast
-	beforeLatestBlock, err := getBlock(&beforeLatestBlockHeight)
-
+	estimate, err := getBlockTimeEstimate(latestBlock, blockHeightProvided)
 	if err != nil {
-		log.Error().Err(err).Msg("getBlockApproximateDate: Could not parse before latest block")
+		log.Error().Err(err).Msg("getBlockApproximateDate: Could not estimate block time")
 		sendMessage(message, "Could not get block info")
 		return
 	}
 
-	heightDiff := float64(latestHeight - beforeLatestBlockHeight)
-	timeDiff := latestBlock.Time.Sub(beforeLatestBlock.Time).Seconds()
+	timeToAddAsDuration := estimate.Best.Sub(latestBlock.Time).Round(time.Second)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<strong>Block #%d</strong>\n", blockHeightProvided))
+	sb.WriteString(fmt.Sprintf("<strong>Estimated generation time: </strong><code>%s</code>\n", estimate.Best.Format(time.RFC822)))
+	sb.WriteString(fmt.Sprintf(
+		"<strong>Range: </strong><code>%s</code> - <code>%s</code>\n",
+		estimate.Earliest.Format(time.RFC822),
+		estimate.Latest.Format(time.RFC822),
+	))
+	sb.WriteString(fmt.Sprintf("<code>%s</code> in the future.\n", timeToAddAsDuration.String()))
+	sb.WriteString("\n<strong>Average block time:</strong>\n")
+	for _, sample := range estimate.Samples {
+		sb.WriteString(fmt.Sprintf("- last %d blocks: <code>%.2fs</code>\n", sample.Blocks, sample.AvgBlockTime))
+	}
 
-	avgBlockTime := timeDiff / heightDiff
+	sendMessage(message, sb.String())
+	log.Info().
+		Int64("height", blockHeightProvided).
+		Str("user", message.Sender.Username).
+		Msg("Successfully returned estimated block time")
+}
 
-	log.Debug().
-		Float64("heightDiff", heightDiff).
-		Float64("timeDiff", timeDiff).
-		Float64("avgBlockTime", avgBlockTime).
-		Msg("Average block time")
+// getEstimatedBlockTime estimates when the future block will be generated,
+// returning the best estimate only.
+func getEstimatedBlockTime(latestBlock *ctypes.Block, height int64) (time.Time, error) {
+	estimate, err := getBlockTimeEstimate(latestBlock, height)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return estimate.Best, nil
+}
+
+// getBlockTimeEstimate estimates when the future block will be generated,
+// based on the average block times of the last BlockTimeWindows blocks.
+func getBlockTimeEstimate(latestBlock *ctypes.Block, height int64) (*BlockTimeEstimate, error) {
+	samples, err := getBlockTimeSamples(latestBlock)
+	if err != nil {
+		return nil, err
+	}
 
-	blocksToCalculate := blockHeightProvided - latestHeight
+	blocksToCalculate := height - latestBlock.Height
 
 	log.Debug().
 		Int64("diff", blocksToCalculate).
 		Msg("Blocks till the specified block")
 
-	latestTime := latestBlock.Time
-	timeToAddAsSeconds := int64(avgBlockTime * float64(blocksToCalculate))
-	timeToAddAsDuration := time.Duration(timeToAddAsSeconds) * time.Second
-	calculatedBlockTime := latestTime.Add(timeToAddAsDuration)
+	estimateWith := func(avgBlockTime float64) time.Time {
+		timeToAdd := time.Duration(avgBlockTime * float64(blocksToCalculate) * float64(time.Second))
+		return latestBlock.Time.Add(timeToAdd)
+	}
+
+	minBlockTime, maxBlockTime := samples[0].AvgBlockTime, samples[0].AvgBlockTime
+	for _, sample := range samples {
+		minBlockTime = math.Min(minBlockTime, sample.AvgBlockTime)
+		maxBlockTime = math.Max(maxBlockTime, sample.AvgBlockTime)
+	}
+
+	estimate := &BlockTimeEstimate{
+		Best:     estimateWith(getMedianBlockTime(samples)),
+		Earliest: estimateWith(minBlockTime),
+		Latest:   estimateWith(maxBlockTime),
+		Samples:  samples,
+	}
 
 	log.Debug().
-		Time("diff", calculatedBlockTime).
+		Time("best", estimate.Best).
+		Time("earliest", estimate.Earliest).
+		Time("latest", estimate.Latest).
 		Msg("Estimated block time")
 
-	log.Debug().
-		Str("diff", timeToAddAsDuration.String()).
-		Msg("Time till block")
+	return estimate, nil
+}
 
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("<strong>Block #%d</strong>\n", blockHeightProvided))
-	sb.WriteString(fmt.Sprintf("<strong>Generation time: </strong><code>%s</code>\n", calculatedBlockTime.Format(time.RFC822)))
-	sb.WriteString(fmt.Sprintf("<code>%s</code> in the future.\n", timeToAddAsDuration.String()))
+// getAverageBlockTime returns the median of the average block times in seconds
+// over the last BlockTimeWindows blocks.
+func getAverageBlockTime(latestBlock *ctypes.Block) (float64, error) {
+	samples, err := getBlockTimeSamples(latestBlock)
+	if err != nil {
+		return 0, err
+	}
 
-	sendMessage(message, sb.String())
+	return getMedianBlockTime(samples), nil
 }
 
-func getBlock(height *int64) (*ctypes.Block, error) {
-	client, err := tmrpc.New(TendermintRpc, "/websocket")
-	if err != nil {
-		log.Error().Err(err).Msg("Could not create Tendermint client")
-		return &ctypes.Block{}, err
+// getBlockTimeSamples calculates the average block time for each of the BlockTimeWindows.
+// Windows that go beyond the chain start are shrunk, and the ones that cannot be fetched
+// (for example, because the node is pruned) are skipped.
+func getBlockTimeSamples(latestBlock *ctypes.Block) ([]BlockTimeSample, error) {
+	samples := []BlockTimeSample{}
+	var lastErr error
+
+	windows := make([]int64, len(BlockTimeWindows))
+	copy(windows, BlockTimeWindows)
+	sort.Slice(windows, func(i, j int) bool {
+		return windows[i] < windows[j]
+	})
+
+	for _, window := range windows {
+		if window >= latestBlock.Height {
+			window = latestBlock.Height - 1
+		}
+
+		if window <= 0 || (len(samples) > 0 && samples[len(samples)-1].Blocks == window) {
+			continue
+		}
+
+		beforeLatestBlockHeight := latestBlock.Height - window
+		beforeLatestBlock, err := getBlock(&beforeLatestBlockHeight)
+		if err != nil {
+			log.Error().Err(err).Int64("window", window).Msg("Could not get block to calculate block time")
+			lastErr = err
+			continue
+		}
+
+		heightDiff := float64(window)
+		timeDiff := latestBlock.Time.Sub(beforeLatestBlock.Time).Seconds()
+		avgBlockTime := timeDiff / heightDiff
+
+		log.Debug().
+			Float64("heightDiff", heightDiff).
+			Float64("timeDiff", timeDiff).
+			Float64("avgBlockTime", avgBlockTime).
+			Msg("Average block time")
+
+		samples = append(samples, BlockTimeSample{Blocks: window, AvgBlockTime: avgBlockTime})
 	}
 
-	block, err := client.Block(context.Background(), height)
+	if len(samples) == 0 {
+		if lastErr == nil {
+			lastErr = fmt.Errorf("not enough blocks to calculate block time")
+		}
+
+		return nil, lastErr
+	}
+
+	return samples, nil
+}
+
+func getMedianBlockTime(samples []BlockTimeSample) float64 {
+	blockTimes := make([]float64, len(samples))
+	for index, sample := range samples {
+		blockTimes[index] = sample.AvgBlockTime
+	}
+
+	sort.Float64s(blockTimes)
+
+	middle := len(blockTimes) / 2
+	if len(blockTimes)%2 == 0 {
+		return (blockTimes[middle-1] + blockTimes[middle]) / 2
+	}
+
+	return blockTimes[middle]
+}
+
+func getBlock(height *int64) (*ctypes.Block, error) {
+	var block *coretypes.ResultBlock
+	err := tendermintClient.Query(func(ctx context.Context, client *tmrpc.HTTP) error {
+		var err error
+		block, err = client.Block(ctx, height)
+		return err
+	})
+
 	if err != nil {
 		log.Error().Err(err).Msg("Could not query Tendermint status")
 		return &ctypes.Block{}, err